@@ -5,55 +5,163 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"strings"
 
+	"tix-scraper/internal/buildinfo"
 	"tix-scraper/internal/cli"
+	"tix-scraper/internal/command"
 )
 
 func main() {
-	botIndex := flag.Int("bot", -1, "Run specific bot by index (-1 for all bots)")
-	help := flag.Bool("help", false, "Show help message")
+	// See cmd/tix-scraper's identical short-circuit for why this runs
+	// ahead of the registry's own flag parsing.
+	if len(os.Args) == 2 && (os.Args[1] == "-v" || os.Args[1] == "--version") {
+		if err := printVersion(false); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	registry := command.NewRegistry("tix-scraper-cli")
+	registry.ExtraTasks = cli.ListBotNames
+
+	registry.Register(&command.Command{
+		Name:  "run",
+		Usage: "Run bots in bots_config.json, optionally filtered by --only-run/--skip/--tag",
+		Flags: func(fs *flag.FlagSet) {
+			fs.String("log-format", "text", "Bot log output format: 'text' or 'json'")
+			fs.String("only-run", "", "Comma-separated bot names/IDs/indices to run (default: every bot)")
+			fs.String("skip", "", "Comma-separated bot names/IDs/indices to exclude")
+			fs.String("tag", "", "Comma-separated tags; only bots carrying at least one run")
+		},
+		Run: func(fs *flag.FlagSet, args []string) error {
+			logFormat := fs.Lookup("log-format").Value.String()
+			if logFormat != "text" && logFormat != "json" {
+				return fmt.Errorf("invalid log format: %s (use 'text' or 'json')", logFormat)
+			}
+			sel := cli.Selection{
+				Only: splitCSV(fs.Lookup("only-run").Value.String()),
+				Skip: splitCSV(fs.Lookup("skip").Value.String()),
+				Tags: splitCSV(fs.Lookup("tag").Value.String()),
+			}
+			log.Println("⚡ Running bots from config...")
+			return cli.Run(logFormat, sel)
+		},
+	})
+
+	registry.Register(&command.Command{
+		Name:  "run-bot",
+		Usage: "Run a single bot by name or index: run-bot <name|index>",
+		Flags: func(fs *flag.FlagSet) {
+			fs.String("log-format", "text", "Bot log output format: 'text' or 'json'")
+		},
+		Run: func(fs *flag.FlagSet, args []string) error {
+			if len(args) != 1 {
+				return fmt.Errorf("run-bot requires exactly one argument: a bot name or index")
+			}
+			logFormat := fs.Lookup("log-format").Value.String()
+			if logFormat != "text" && logFormat != "json" {
+				return fmt.Errorf("invalid log format: %s (use 'text' or 'json')", logFormat)
+			}
+			log.Printf("⚡ Running bot %q\n", args[0])
+			return cli.RunBot(args[0], logFormat)
+		},
+	})
+
+	registry.Register(&command.Command{
+		Name:  "list",
+		Usage: "List every bot configured in bots_config.json",
+		Run: func(fs *flag.FlagSet, args []string) error {
+			names := cli.ListBotNames()
+			if len(names) == 0 {
+				fmt.Println("No bots configured in bots_config.json")
+				return nil
+			}
+			for _, n := range names {
+				fmt.Println(n)
+			}
+			return nil
+		},
+	})
+
+	registry.Register(&command.Command{
+		Name:  "validate",
+		Usage: "Validate bots_config.json without running anything",
+		Run: func(fs *flag.FlagSet, args []string) error {
+			if err := cli.ValidateBots(); err != nil {
+				return err
+			}
+			fmt.Println("✅ bots_config.json is valid")
+			return nil
+		},
+	})
+
+	registry.Register(&command.Command{
+		Name:  "version",
+		Usage: "Print version, commit, build date, Go version, and enabled feature tags",
+		Flags: func(fs *flag.FlagSet) {
+			fs.Bool("json", false, "Print version info as JSON instead of a human-readable line")
+		},
+		Run: func(fs *flag.FlagSet, args []string) error {
+			return printVersion(fs.Lookup("json").Value.String() == "true")
+		},
+	})
 
-	flag.Parse()
+	registry.Register(&command.Command{
+		Name:  "completion",
+		Usage: "Print a shell completion script: completion [bash|zsh|fish|powershell]",
+		Run: func(fs *flag.FlagSet, args []string) error {
+			shell := "bash"
+			if len(args) == 1 {
+				shell = args[0]
+			} else if len(args) > 1 {
+				return fmt.Errorf("completion takes at most one argument (the shell name)")
+			}
+			script, err := cli.Completion(shell, "tix-scraper-cli", append(registry.CommandNames(), "help"))
+			if err != nil {
+				return err
+			}
+			fmt.Print(script)
+			return nil
+		},
+	})
 
-	if *help {
-		printHelp()
-		os.Exit(0)
+	if err := registry.Dispatch(os.Args[1:]); err != nil {
+		log.Fatal(err)
 	}
+}
 
-	log.Println("⚡ Starting CLI mode...")
-	if *botIndex >= 0 {
-		log.Printf("Running bot #%d\n", *botIndex)
-		if err := cli.RunSingle(*botIndex); err != nil {
-			log.Fatal(err)
-		}
-	} else {
-		log.Println("Running all bots from config...")
-		if err := cli.Run(); err != nil {
-			log.Fatal(err)
-		}
+// printVersion prints buildinfo.Get() as a human-readable line or, if
+// asJSON, as indented JSON — shared by the version command and the
+// -v/--version shortcut so both report identically.
+func printVersion(asJSON bool) error {
+	info := buildinfo.Get()
+	if !asJSON {
+		fmt.Println("tix-scraper-cli " + info.String())
+		return nil
 	}
+	out, err := info.JSON()
+	if err != nil {
+		return err
+	}
+	fmt.Println(out)
+	return nil
 }
 
-func printHelp() {
-	fmt.Println(`
-Tix Scraper CLI - Multi-Bot Runner
-===================================
-
-Usage:
-  tix-scraper-cli [options]
-
-Options:
-  -bot int
-        Run specific bot by index (-1 for all bots)
-  
-  -help
-        Show this help message
-
-Examples:
-  # Run all bots
-  go run cmd/tix-scraper-cli/main.go
-  
-  # Run specific bot
-  go run cmd/tix-scraper-cli/main.go -bot=0
-`)
+// splitCSV splits a comma-separated flag value into its trimmed parts,
+// returning nil for an empty string rather than []string{""} — so an
+// unset --only-run/--skip/--tag flag leaves the corresponding
+// cli.Selection field empty instead of matching an empty name.
+func splitCSV(s string) []string {
+	if s == "" {
+		return nil
+	}
+	parts := strings.Split(s, ",")
+	out := make([]string, 0, len(parts))
+	for _, p := range parts {
+		if p = strings.TrimSpace(p); p != "" {
+			out = append(out, p)
+		}
+	}
+	return out
 }
@@ -4,86 +4,243 @@ import (
 	"flag"
 	"fmt"
 	"log"
+	"net/http"
 	"os"
+	"strings"
+	"time"
 
+	"tix-scraper/internal/buildinfo"
 	"tix-scraper/internal/cli"
+	"tix-scraper/internal/command"
 	"tix-scraper/internal/gui"
+	"tix-scraper/internal/tui"
 )
 
 func main() {
-	// Define command-line flags
-	mode := flag.String("mode", "gui", "Run mode: 'gui' or 'cli'")
-	botIndex := flag.Int("bot", -1, "Run specific bot by index (CLI mode only, -1 for all bots)")
-	help := flag.Bool("help", false, "Show help message")
+	// -v/--version is short-circuited ahead of the registry's own flag
+	// parsing (mitchellh/cli's RunCustom does the same for its global
+	// -version flag), so `tix-scraper -v` works the same as `tix-scraper
+	// version` without the registry having to special-case a global flag
+	// alongside every subcommand's own FlagSet.
+	if len(os.Args) == 2 && (os.Args[1] == "-v" || os.Args[1] == "--version") {
+		if err := printVersion(false); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
 
-	flag.Parse()
+	registry := command.NewRegistry("tix-scraper")
+	registry.ExtraTasks = cli.ListBotNames
 
-	// Show help
-	if *help {
-		printHelp()
-		os.Exit(0)
-	}
+	registry.Register(&command.Command{
+		Name:  "gui",
+		Usage: "Launch the Gio desktop GUI (or the terminal UI with -tui)",
+		Flags: func(fs *flag.FlagSet) {
+			fs.Bool("tray", false, "Start minimized to the system tray and keep bots running when the window is closed")
+			fs.String("config-dir", "", "Directory for bot configs and the encrypted vault (default: OS per-user config dir, or $TIX_CONFIG_DIR)")
+			fs.Bool("tui", false, "Launch the terminal UI instead of the Gio window (for SSH sessions and headless CI)")
+			fs.String("http", "", "Address for an optional HTTP dashboard (e.g. ':8090') exposing bot status and logs alongside the GUI/TUI")
+		},
+		Run: runGUI,
+	})
 
-	// Run based on mode
-	switch *mode {
-	case "gui":
-		log.Println("🎨 Starting GUI mode...")
-		gui.NewGUI().Run()
-
-	case "cli":
-		log.Println("⚡ Starting CLI mode...")
-		if *botIndex >= 0 {
-			// Run single bot
-			log.Printf("Running bot #%d\n", *botIndex)
-			if err := cli.RunSingle(*botIndex); err != nil {
-				log.Fatal(err)
+	registry.Register(&command.Command{
+		Name:  "run",
+		Usage: "Run bots in bots_config.json, optionally filtered by --only-run/--skip/--tag",
+		Flags: func(fs *flag.FlagSet) {
+			fs.String("log-format", "text", "Bot log output format: 'text' or 'json'")
+			fs.String("only-run", "", "Comma-separated bot names/IDs/indices to run (default: every bot)")
+			fs.String("skip", "", "Comma-separated bot names/IDs/indices to exclude")
+			fs.String("tag", "", "Comma-separated tags; only bots carrying at least one run")
+		},
+		Run: func(fs *flag.FlagSet, args []string) error {
+			logFormat := fs.Lookup("log-format").Value.String()
+			if logFormat != "text" && logFormat != "json" {
+				return fmt.Errorf("invalid log format: %s (use 'text' or 'json')", logFormat)
 			}
-		} else {
-			// Run all bots
-			log.Println("Running all bots from config...")
-			if err := cli.Run(); err != nil {
-				log.Fatal(err)
+			sel := cli.Selection{
+				Only: splitCSV(fs.Lookup("only-run").Value.String()),
+				Skip: splitCSV(fs.Lookup("skip").Value.String()),
+				Tags: splitCSV(fs.Lookup("tag").Value.String()),
 			}
-		}
+			log.Println("⚡ Running bots from config...")
+			return cli.Run(logFormat, sel)
+		},
+	})
+
+	registry.Register(&command.Command{
+		Name:  "run-bot",
+		Usage: "Run a single bot by name or index: run-bot <name|index>",
+		Flags: func(fs *flag.FlagSet) {
+			fs.String("log-format", "text", "Bot log output format: 'text' or 'json'")
+		},
+		Run: func(fs *flag.FlagSet, args []string) error {
+			if len(args) != 1 {
+				return fmt.Errorf("run-bot requires exactly one argument: a bot name or index")
+			}
+			logFormat := fs.Lookup("log-format").Value.String()
+			if logFormat != "text" && logFormat != "json" {
+				return fmt.Errorf("invalid log format: %s (use 'text' or 'json')", logFormat)
+			}
+			log.Printf("⚡ Running bot %q\n", args[0])
+			return cli.RunBot(args[0], logFormat)
+		},
+	})
+
+	registry.Register(&command.Command{
+		Name:  "list",
+		Usage: "List every bot configured in bots_config.json",
+		Run: func(fs *flag.FlagSet, args []string) error {
+			names := cli.ListBotNames()
+			if len(names) == 0 {
+				fmt.Println("No bots configured in bots_config.json")
+				return nil
+			}
+			for _, n := range names {
+				fmt.Println(n)
+			}
+			return nil
+		},
+	})
+
+	registry.Register(&command.Command{
+		Name:  "validate",
+		Usage: "Validate bots_config.json without running anything",
+		Run: func(fs *flag.FlagSet, args []string) error {
+			if err := cli.ValidateBots(); err != nil {
+				return err
+			}
+			fmt.Println("✅ bots_config.json is valid")
+			return nil
+		},
+	})
+
+	registry.Register(&command.Command{
+		Name:  "login",
+		Usage: "Log in via QR code scan instead of pasting a session cookie",
+		Flags: func(fs *flag.FlagSet) {
+			fs.Duration("timeout", 2*time.Minute, "How long to wait for the QR code to be scanned")
+		},
+		Run: func(fs *flag.FlagSet, args []string) error {
+			timeout := fs.Lookup("timeout").Value.(flag.Getter).Get().(time.Duration)
+			log.Println("📱 Starting QR login...")
+			return cli.Login(timeout)
+		},
+	})
 
+	registry.Register(&command.Command{
+		Name:  "interactive",
+		Usage: "Walk through creating a new bot config (alias: wizard)",
+		Run: func(fs *flag.FlagSet, args []string) error {
+			return cli.RunWizard(os.Stdin, os.Stdout)
+		},
+	})
+	registry.Register(&command.Command{
+		Name:  "wizard",
+		Usage: "Alias for interactive",
+		Run: func(fs *flag.FlagSet, args []string) error {
+			return cli.RunWizard(os.Stdin, os.Stdout)
+		},
+	})
+
+	registry.Register(&command.Command{
+		Name:  "version",
+		Usage: "Print version, commit, build date, Go version, and enabled feature tags",
+		Flags: func(fs *flag.FlagSet) {
+			fs.Bool("json", false, "Print version info as JSON instead of a human-readable line")
+		},
+		Run: func(fs *flag.FlagSet, args []string) error {
+			return printVersion(fs.Lookup("json").Value.String() == "true")
+		},
+	})
+
+	registry.Register(&command.Command{
+		Name:  "completion",
+		Usage: "Print a shell completion script: completion [bash|zsh|fish|powershell]",
+		Run: func(fs *flag.FlagSet, args []string) error {
+			shell := "bash"
+			if len(args) == 1 {
+				shell = args[0]
+			} else if len(args) > 1 {
+				return fmt.Errorf("completion takes at most one argument (the shell name)")
+			}
+			script, err := cli.Completion(shell, "tix-scraper", append(registry.CommandNames(), "help"))
+			if err != nil {
+				return err
+			}
+			fmt.Print(script)
+			return nil
+		},
+	})
+
+	if err := registry.Dispatch(os.Args[1:]); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func runGUI(fs *flag.FlagSet, args []string) error {
+	configDir := fs.Lookup("config-dir").Value.String()
+	httpAddr := fs.Lookup("http").Value.String()
+	runTUI := fs.Lookup("tui").Value.String() == "true"
+	tray := fs.Lookup("tray").Value.String() == "true"
+
+	g := gui.NewGUI(configDir)
+
+	if httpAddr != "" {
+		srv := gui.NewDashboardServer(g, httpAddr)
+		go func() {
+			log.Printf("📊 Dashboard listening on http://%s\n", httpAddr)
+			if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				log.Printf("❌ Dashboard error: %v\n", err)
+			}
+		}()
+	}
+
+	switch {
+	case runTUI:
+		log.Println("🖥️  Starting terminal UI...")
+		return tui.Run(g, os.Stdin, os.Stdout)
+	case tray:
+		log.Println("🎨 Starting GUI in tray mode...")
+		g.RunTray()
 	default:
-		log.Fatalf("Invalid mode: %s (use 'gui' or 'cli')", *mode)
+		log.Println("🎨 Starting GUI mode...")
+		g.Run()
+	}
+	return nil
+}
+
+// printVersion prints buildinfo.Get() as a human-readable line or, if
+// asJSON, as indented JSON — shared by the version command and the
+// -v/--version shortcut so both report identically.
+func printVersion(asJSON bool) error {
+	info := buildinfo.Get()
+	if !asJSON {
+		fmt.Println("tix-scraper " + info.String())
+		return nil
 	}
+	out, err := info.JSON()
+	if err != nil {
+		return err
+	}
+	fmt.Println(out)
+	return nil
 }
 
-func printHelp() {
-	fmt.Println(`
-Tix Scraper - Multi-Bot Ticket Scraper
-========================================
-
-Usage:
-  tix-scraper [options]
-
-Options:
-  -mode string
-        Run mode: 'gui' or 'cli' (default "gui")
-  
-  -bot int
-        Run specific bot by index in CLI mode
-        Use -1 to run all bots (default -1)
-  
-  -help
-        Show this help message
-
-Examples:
-  # Start GUI (default)
-  ./tix-scraper
-  
-  # Start GUI explicitly
-  ./tix-scraper -mode=gui
-  
-  # Run all bots from config in CLI mode
-  ./tix-scraper -mode=cli
-  
-  # Run specific bot (first bot = index 0)
-  ./tix-scraper -mode=cli -bot=0
-  
-  # Run second bot
-  ./tix-scraper -mode=cli -bot=1
-`)
+// splitCSV splits a comma-separated flag value into its trimmed parts,
+// returning nil for an empty string rather than []string{""} — so an
+// unset --only-run/--skip/--tag flag leaves the corresponding
+// cli.Selection field empty instead of matching an empty name.
+func splitCSV(s string) []string {
+	if s == "" {
+		return nil
+	}
+	parts := strings.Split(s, ",")
+	out := make([]string, 0, len(parts))
+	for _, p := range parts {
+		if p = strings.TrimSpace(p); p != "" {
+			out = append(out, p)
+		}
+	}
+	return out
 }
@@ -0,0 +1,31 @@
+// Package android bridges a bot's scheduled start to Android's AlarmManager
+// and a foreground Service, so the bot still fires at its exact
+// StartDate+StartTime after the app is backgrounded or the process is
+// killed under Doze. On every other platform, Default is a no-op and the
+// GUI's in-process wait (gui.waitForScheduledTime) is used instead.
+package android
+
+import "time"
+
+// Scheduler hands a scheduled bot off to the platform's exact-alarm
+// facility. payload is the bot's BotConfig JSON, handed back verbatim to
+// the alarm receiver so it can resume the exact same run; see
+// android/TixAlarmReceiver.java.
+type Scheduler interface {
+	// ScheduleExactWake arranges for fireAt to wake the app via
+	// AlarmManager.setExactAndAllowWhileIdle, keyed by botID so a
+	// reschedule (or CancelWake) can replace or remove it.
+	ScheduleExactWake(botID string, fireAt time.Time, payload []byte) error
+
+	// CancelWake removes a previously scheduled alarm. Canceling an
+	// unknown botID is not an error.
+	CancelWake(botID string) error
+}
+
+// Supported reports whether this build has a real Scheduler (Android).
+// Callers should fall back to an in-process wait when false.
+func Supported() bool { return supported }
+
+// Default is the platform Scheduler: a working AlarmManager bridge on
+// Android, a no-op everywhere else.
+var Default Scheduler = defaultScheduler()
@@ -0,0 +1,144 @@
+//go:build android
+
+package android
+
+/*
+#cgo LDFLAGS: -llog
+
+#include <jni.h>
+#include <stdlib.h>
+
+static jclass find_class(JNIEnv *env, const char *name) {
+	jclass local = (*env)->FindClass(env, name);
+	if (local == NULL) {
+		return NULL;
+	}
+	jclass global = (jclass)(*env)->NewGlobalRef(env, local);
+	(*env)->DeleteLocalRef(env, local);
+	return global;
+}
+
+// callScheduleExactAlarm and callCancelAlarm call the matching static
+// helper methods on TixAlarmBridge (see android/TixAlarmBridge.java),
+// which do the actual AlarmManager.setExactAndAllowWhileIdle / cancel work
+// on the Java side; Go only ever talks to this one bridge class.
+static void callScheduleExactAlarm(JNIEnv *env, jobject ctx, jstring botID, jlong fireAtMillis, jbyteArray payload) {
+	jclass bridge = find_class(env, "org/tixscraper/app/TixAlarmBridge");
+	if (bridge == NULL) {
+		return;
+	}
+	jmethodID m = (*env)->GetStaticMethodID(env, bridge, "scheduleExactAlarm",
+		"(Landroid/content/Context;Ljava/lang/String;J[B)V");
+	if (m != NULL) {
+		(*env)->CallStaticVoidMethod(env, bridge, m, ctx, botID, fireAtMillis, payload);
+	}
+	(*env)->DeleteGlobalRef(env, bridge);
+}
+
+static void callCancelAlarm(JNIEnv *env, jobject ctx, jstring botID) {
+	jclass bridge = find_class(env, "org/tixscraper/app/TixAlarmBridge");
+	if (bridge == NULL) {
+		return;
+	}
+	jmethodID m = (*env)->GetStaticMethodID(env, bridge, "cancelAlarm",
+		"(Landroid/content/Context;Ljava/lang/String;)V");
+	if (m != NULL) {
+		(*env)->CallStaticVoidMethod(env, bridge, m, ctx, botID);
+	}
+	(*env)->DeleteGlobalRef(env, bridge);
+}
+
+static jstring new_string(JNIEnv *env, const char *s) {
+	return (*env)->NewStringUTF(env, s);
+}
+
+static jbyteArray new_byte_array(JNIEnv *env, const void *data, int len) {
+	jbyteArray arr = (*env)->NewByteArray(env, len);
+	if (arr != NULL && len > 0) {
+		(*env)->SetByteArrayRegion(env, arr, 0, len, (const jbyte *)data);
+	}
+	return arr;
+}
+
+static void delete_local_ref(JNIEnv *env, jobject obj) {
+	(*env)->DeleteLocalRef(env, obj);
+}
+
+static int attach_current_thread(JavaVM *vm, JNIEnv **env) {
+	return (*vm)->AttachCurrentThread(vm, env, NULL);
+}
+
+static void detach_current_thread(JavaVM *vm) {
+	(*vm)->DetachCurrentThread(vm);
+}
+*/
+import "C"
+
+import (
+	"fmt"
+	"time"
+	"unsafe"
+
+	"gioui.org/app"
+)
+
+const supported = true
+
+// androidScheduler bridges to TixAlarmBridge.java over JNI, using the
+// JavaVM/Context handles Gio's app package exposes for exactly this kind
+// of platform-service integration.
+type androidScheduler struct{}
+
+func defaultScheduler() Scheduler { return androidScheduler{} }
+
+func (androidScheduler) ScheduleExactWake(botID string, fireAt time.Time, payload []byte) error {
+	return withJNIEnv(func(env *C.JNIEnv, ctx C.jobject) error {
+		jBotID := newJString(env, botID)
+		defer C.delete_local_ref(env, C.jobject(jBotID))
+
+		var data unsafe.Pointer
+		if len(payload) > 0 {
+			data = unsafe.Pointer(&payload[0])
+		}
+		jPayload := C.new_byte_array(env, data, C.int(len(payload)))
+		defer C.delete_local_ref(env, C.jobject(jPayload))
+
+		C.callScheduleExactAlarm(env, ctx, jBotID, C.jlong(fireAt.UnixMilli()), jPayload)
+		return nil
+	})
+}
+
+func (androidScheduler) CancelWake(botID string) error {
+	return withJNIEnv(func(env *C.JNIEnv, ctx C.jobject) error {
+		jBotID := newJString(env, botID)
+		defer C.delete_local_ref(env, C.jobject(jBotID))
+
+		C.callCancelAlarm(env, ctx, jBotID)
+		return nil
+	})
+}
+
+func newJString(env *C.JNIEnv, s string) C.jstring {
+	cs := C.CString(s)
+	defer C.free(unsafe.Pointer(cs))
+	return C.new_string(env, cs)
+}
+
+// withJNIEnv attaches the calling goroutine's OS thread to the JVM Gio is
+// already running under, and runs fn with the resulting JNIEnv and the
+// app's Context (both required by every Context-scoped Android API).
+func withJNIEnv(fn func(env *C.JNIEnv, ctx C.jobject) error) error {
+	vm := (*C.JavaVM)(unsafe.Pointer(app.JavaVM()))
+	if vm == nil {
+		return fmt.Errorf("android: no JavaVM attached")
+	}
+
+	var env *C.JNIEnv
+	if C.attach_current_thread(vm, &env) != 0 {
+		return fmt.Errorf("android: AttachCurrentThread failed")
+	}
+	defer C.detach_current_thread(vm)
+
+	ctx := C.jobject(unsafe.Pointer(app.AppContext()))
+	return fn(env, ctx)
+}
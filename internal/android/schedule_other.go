@@ -0,0 +1,19 @@
+//go:build !android
+
+package android
+
+import "time"
+
+const supported = false
+
+// noopScheduler is used on every platform but Android, where the GUI
+// already keeps its own process alive to wait out a schedule in-process.
+type noopScheduler struct{}
+
+func defaultScheduler() Scheduler { return noopScheduler{} }
+
+func (noopScheduler) ScheduleExactWake(botID string, fireAt time.Time, payload []byte) error {
+	return nil
+}
+
+func (noopScheduler) CancelWake(botID string) error { return nil }
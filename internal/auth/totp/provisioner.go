@@ -0,0 +1,220 @@
+package totp
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"tix-scraper/internal/vault"
+)
+
+// Secret is everything needed to generate codes for one site, plus the
+// display fields an enrollment screen shows back to the operator.
+type Secret struct {
+	Site      string    `json:"site"`       // key the scraper looks this secret up by, e.g. a bot's EventID host
+	Issuer    string    `json:"issuer"`     // "StubHub", "Ticketmaster Business", ...
+	Account   string    `json:"account"`    // username/email the authenticator entry is labeled with
+	SecretB32 string    `json:"secret_b32"` // base32, as handed out by the site
+	Digits    int       `json:"digits"`
+	Period    int       `json:"period_seconds"`
+	Algorithm Algorithm `json:"algorithm"`
+}
+
+func (s Secret) period() time.Duration {
+	if s.Period <= 0 {
+		return DefaultPeriod
+	}
+	return time.Duration(s.Period) * time.Second
+}
+
+func (s Secret) digits() int {
+	if s.Digits <= 0 {
+		return DefaultDigits
+	}
+	return s.Digits
+}
+
+// Provisioner stores per-site TOTP secrets in an encrypted vault and
+// generates codes from them on demand. It deliberately reuses
+// internal/vault's existing Argon2id-derived-key, authenticated-encryption
+// format rather than standing up a second on-disk encryption scheme next
+// to it — one vault implementation to audit is safer than two.
+type Provisioner struct {
+	vault *vault.Vault
+}
+
+// NewProvisioner returns a Provisioner backed by an encrypted file at
+// path. Unlock it (see Unlock) before calling Code or Enroll.
+func NewProvisioner(path string, lockTimeout time.Duration) *Provisioner {
+	return &Provisioner{vault: vault.New(path, lockTimeout)}
+}
+
+// Unlock derives the vault key from passphrase; see vault.Vault.Unlock.
+func (p *Provisioner) Unlock(passphrase string) error {
+	return p.vault.Unlock(passphrase)
+}
+
+// Lock zeroizes the in-memory key; see vault.Vault.Lock.
+func (p *Provisioner) Lock() {
+	p.vault.Lock()
+}
+
+func (p *Provisioner) load() (map[string]Secret, error) {
+	secrets := make(map[string]Secret)
+	if err := p.vault.Load(&secrets); err != nil {
+		return nil, err
+	}
+	return secrets, nil
+}
+
+// Enroll stores secret under secret.Site, overwriting any existing entry
+// for that site.
+func (p *Provisioner) Enroll(secret Secret) error {
+	if secret.Site == "" {
+		return fmt.Errorf("totp: secret needs a non-empty Site")
+	}
+	if _, err := DecodeSecret(secret.SecretB32); err != nil {
+		return fmt.Errorf("totp: invalid base32 secret: %w", err)
+	}
+
+	secrets, err := p.load()
+	if err != nil {
+		return err
+	}
+	secrets[secret.Site] = secret
+	return p.vault.Save(secrets)
+}
+
+// Remove deletes the stored secret for site, if any.
+func (p *Provisioner) Remove(site string) error {
+	secrets, err := p.load()
+	if err != nil {
+		return err
+	}
+	delete(secrets, site)
+	return p.vault.Save(secrets)
+}
+
+// Sites lists every site with an enrolled secret.
+func (p *Provisioner) Sites() ([]string, error) {
+	secrets, err := p.load()
+	if err != nil {
+		return nil, err
+	}
+	sites := make([]string, 0, len(secrets))
+	for site := range secrets {
+		sites = append(sites, site)
+	}
+	return sites, nil
+}
+
+// Code returns the current TOTP code for site and how long it remains
+// valid before rotating.
+func (p *Provisioner) Code(site string, now time.Time) (string, time.Duration, error) {
+	secrets, err := p.load()
+	if err != nil {
+		return "", 0, err
+	}
+	secret, ok := secrets[site]
+	if !ok {
+		return "", 0, fmt.Errorf("totp: no secret enrolled for site %q", site)
+	}
+
+	key, err := DecodeSecret(secret.SecretB32)
+	if err != nil {
+		return "", 0, fmt.Errorf("totp: decoding stored secret for %q: %w", site, err)
+	}
+
+	code, err := GenerateCode(key, now, secret.digits(), secret.period(), secret.Algorithm)
+	if err != nil {
+		return "", 0, err
+	}
+	return code, NextRotation(now, secret.period()), nil
+}
+
+// ParseOTPAuthURI parses an "otpauth://totp/..." URI, as produced by a
+// vendor's enrollment QR code, into a Secret. site is the caller-chosen
+// key to store it under (the URI itself has no notion of "which bot uses
+// this"), since the same otpauth label can be reused across bots targeting
+// the same vendor account.
+func ParseOTPAuthURI(site, uri string) (Secret, error) {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return Secret{}, fmt.Errorf("totp: parsing otpauth URI: %w", err)
+	}
+	if u.Scheme != "otpauth" || u.Host != "totp" {
+		return Secret{}, fmt.Errorf("totp: not an otpauth://totp URI")
+	}
+
+	label := strings.TrimPrefix(u.Path, "/")
+	issuer, account := "", label
+	if idx := strings.Index(label, ":"); idx >= 0 {
+		issuer, account = label[:idx], label[idx+1:]
+	}
+
+	q := u.Query()
+	if v := q.Get("issuer"); v != "" {
+		issuer = v
+	}
+
+	secret := Secret{
+		Site:      site,
+		Issuer:    issuer,
+		Account:   account,
+		SecretB32: q.Get("secret"),
+		Digits:    DefaultDigits,
+		Period:    int(DefaultPeriod.Seconds()),
+		Algorithm: AlgorithmSHA1,
+	}
+	if secret.SecretB32 == "" {
+		return Secret{}, fmt.Errorf("totp: otpauth URI missing secret parameter")
+	}
+	if v := q.Get("digits"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil {
+			secret.Digits = n
+		}
+	}
+	if v := q.Get("period"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil {
+			secret.Period = n
+		}
+	}
+	if v := q.Get("algorithm"); v != "" {
+		secret.Algorithm = Algorithm(strings.ToUpper(v))
+	}
+
+	return secret, nil
+}
+
+// Backup returns every enrolled secret as indented JSON, for migrating
+// secrets between machines. The output is plaintext, so callers are
+// responsible for protecting it in transit (e.g. an encrypted USB drive
+// or a one-time-use channel) the same way they would a password export.
+func (p *Provisioner) Backup() ([]byte, error) {
+	secrets, err := p.load()
+	if err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(secrets, "", "  ")
+}
+
+// Restore merges the secrets encoded in data (as produced by Backup) into
+// the vault, overwriting any existing entries with the same Site.
+func (p *Provisioner) Restore(data []byte) error {
+	var incoming map[string]Secret
+	if err := json.Unmarshal(data, &incoming); err != nil {
+		return fmt.Errorf("totp: parsing backup: %w", err)
+	}
+
+	secrets, err := p.load()
+	if err != nil {
+		return err
+	}
+	for site, secret := range incoming {
+		secrets[site] = secret
+	}
+	return p.vault.Save(secrets)
+}
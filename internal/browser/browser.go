@@ -0,0 +1,46 @@
+// Package browser abstracts the handful of browser-automation primitives
+// tix-scraper actually uses (navigate, evaluate JS, set a cookie, wait for
+// an element, click, screenshot) behind one interface, so call sites that
+// only need these can run on chromedp (the original and still default
+// backend) or on Playwright (see playwright.go) without caring which one
+// is driving the browser underneath.
+package browser
+
+import "context"
+
+// Cookie is the subset of cookie fields every Browser.SetCookie
+// implementation needs; callers that need more (SameSite, HttpOnly, an
+// expiry) should keep using the underlying driver directly, the same way
+// services/cookies.go talks to chromedp's network.SetCookie for its
+// EditThisCookie import/export rather than going through this interface.
+type Cookie struct {
+	Name   string
+	Value  string
+	Domain string
+	Path   string
+}
+
+// Browser is implemented by ChromedpBrowser and (with `-tags playwright`)
+// PlaywrightBrowser. Every method takes ctx as the first argument, the
+// same convention the rest of this codebase already uses to thread
+// cancellation/timeouts through chromedp.Run calls.
+type Browser interface {
+	// Navigate loads url in the current page.
+	Navigate(ctx context.Context, url string) error
+
+	// Eval runs expression as JavaScript and decodes its result into
+	// result (which must be a pointer, as with chromedp.Evaluate).
+	Eval(ctx context.Context, expression string, result interface{}) error
+
+	// SetCookie sets a single cookie before/during navigation.
+	SetCookie(ctx context.Context, cookie Cookie) error
+
+	// WaitVisible blocks until the element matching selector is visible.
+	WaitVisible(ctx context.Context, selector string) error
+
+	// Click clicks the element matching selector.
+	Click(ctx context.Context, selector string) error
+
+	// Screenshot captures the current page as PNG-encoded bytes.
+	Screenshot(ctx context.Context) ([]byte, error)
+}
@@ -0,0 +1,51 @@
+package browser
+
+import (
+	"context"
+
+	"github.com/chromedp/cdproto/network"
+	"github.com/chromedp/chromedp"
+)
+
+// ChromedpBrowser is the original Browser backend: every method is a thin
+// wrapper around the equivalent chromedp.Run call, operating on whatever
+// chromedp context ctx is (e.g. one returned by services.getBrowserContext
+// or chromedp.NewContext directly, as GetUserName's short-lived session
+// does). It carries no state of its own, so the zero value is ready to
+// use.
+type ChromedpBrowser struct{}
+
+func (ChromedpBrowser) Navigate(ctx context.Context, url string) error {
+	return chromedp.Run(ctx, chromedp.Navigate(url))
+}
+
+func (ChromedpBrowser) Eval(ctx context.Context, expression string, result interface{}) error {
+	return chromedp.Run(ctx, chromedp.Evaluate(expression, result))
+}
+
+func (ChromedpBrowser) SetCookie(ctx context.Context, cookie Cookie) error {
+	set := network.SetCookie(cookie.Name, cookie.Value)
+	if cookie.Domain != "" {
+		set = set.WithDomain(cookie.Domain)
+	}
+	if cookie.Path != "" {
+		set = set.WithPath(cookie.Path)
+	}
+	return chromedp.Run(ctx, set)
+}
+
+func (ChromedpBrowser) WaitVisible(ctx context.Context, selector string) error {
+	return chromedp.Run(ctx, chromedp.WaitVisible(selector, chromedp.ByQuery))
+}
+
+func (ChromedpBrowser) Click(ctx context.Context, selector string) error {
+	return chromedp.Run(ctx, chromedp.Click(selector, chromedp.ByQuery))
+}
+
+func (ChromedpBrowser) Screenshot(ctx context.Context) ([]byte, error) {
+	var buf []byte
+	if err := chromedp.Run(ctx, chromedp.CaptureScreenshot(&buf)); err != nil {
+		return nil, err
+	}
+	return buf, nil
+}
@@ -0,0 +1,41 @@
+//go:build !playwright
+
+package browser
+
+import (
+	"context"
+	"fmt"
+)
+
+// PlaywrightBrowser is the no-tag stand-in for playwright.go's real
+// implementation, so ScraperConfig.Browser and config-driven engine
+// selection can reference PlaywrightBrowser unconditionally. Build with
+// `-tags playwright` (and github.com/playwright-community/playwright-go,
+// plus its driver/browser download — neither is a dependency of this
+// module by default) to actually drive a Playwright-controlled browser;
+// otherwise every method just reports why it can't run.
+type PlaywrightBrowser struct{}
+
+func (PlaywrightBrowser) Navigate(ctx context.Context, url string) error {
+	return fmt.Errorf("browser: PlaywrightBrowser requires building with -tags playwright")
+}
+
+func (PlaywrightBrowser) Eval(ctx context.Context, expression string, result interface{}) error {
+	return fmt.Errorf("browser: PlaywrightBrowser requires building with -tags playwright")
+}
+
+func (PlaywrightBrowser) SetCookie(ctx context.Context, cookie Cookie) error {
+	return fmt.Errorf("browser: PlaywrightBrowser requires building with -tags playwright")
+}
+
+func (PlaywrightBrowser) WaitVisible(ctx context.Context, selector string) error {
+	return fmt.Errorf("browser: PlaywrightBrowser requires building with -tags playwright")
+}
+
+func (PlaywrightBrowser) Click(ctx context.Context, selector string) error {
+	return fmt.Errorf("browser: PlaywrightBrowser requires building with -tags playwright")
+}
+
+func (PlaywrightBrowser) Screenshot(ctx context.Context) ([]byte, error) {
+	return nil, fmt.Errorf("browser: PlaywrightBrowser requires building with -tags playwright")
+}
@@ -0,0 +1,76 @@
+// Package buildinfo holds version metadata for release binaries and a
+// formatter for the `version` subcommand (and its `-v`/`--version`
+// shortcut) in cmd/tix-scraper and cmd/tix-scraper-cli.
+package buildinfo
+
+import (
+	"encoding/json"
+	"fmt"
+	"runtime"
+	"sort"
+)
+
+// Version, Commit, and Date are meant to be set at build time via:
+//
+//	go build -ldflags "-X tix-scraper/internal/buildinfo.Version=v1.2.3 \
+//	  -X tix-scraper/internal/buildinfo.Commit=$(git rev-parse --short HEAD) \
+//	  -X tix-scraper/internal/buildinfo.Date=$(date -u +%Y-%m-%dT%H:%M:%SZ)"
+//
+// No such -ldflags wiring exists in this repo's build yet (see
+// .goreleaser.yml / Makefile if one gets added); left unset they fall back
+// to "dev"/"unknown" rather than an empty string.
+var (
+	Version = "dev"
+	Commit  = "unknown"
+	Date    = "unknown"
+)
+
+// featureTags is appended to by each optional build tag's own file (see
+// feature_*.go in this package) so Info can report which were compiled in
+// without needing runtime reflection over build constraints.
+var featureTags []string
+
+// Info is everything the version subcommand reports, in both its
+// human-readable and --json forms.
+type Info struct {
+	Version   string   `json:"version"`
+	Commit    string   `json:"commit"`
+	Date      string   `json:"date"`
+	GoVersion string   `json:"go_version"`
+	Features  []string `json:"features"`
+}
+
+// Get returns the current build's Info.
+func Get() Info {
+	features := append([]string(nil), featureTags...)
+	sort.Strings(features)
+	if features == nil {
+		features = []string{}
+	}
+	return Info{
+		Version:   Version,
+		Commit:    Commit,
+		Date:      Date,
+		GoVersion: runtime.Version(),
+		Features:  features,
+	}
+}
+
+// String renders Info for human-readable `version` output.
+func (i Info) String() string {
+	features := "none"
+	if len(i.Features) > 0 {
+		features = fmt.Sprint(i.Features)
+	}
+	return fmt.Sprintf("version %s (commit %s, built %s, %s, features: %s)",
+		i.Version, i.Commit, i.Date, i.GoVersion, features)
+}
+
+// JSON renders Info as indented JSON for `version --json`.
+func (i Info) JSON() (string, error) {
+	data, err := json.MarshalIndent(i, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("buildinfo: encoding version info: %w", err)
+	}
+	return string(data), nil
+}
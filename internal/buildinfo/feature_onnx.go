@@ -0,0 +1,5 @@
+//go:build onnx
+
+package buildinfo
+
+func init() { featureTags = append(featureTags, "onnx") }
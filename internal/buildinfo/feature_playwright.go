@@ -0,0 +1,5 @@
+//go:build playwright
+
+package buildinfo
+
+func init() { featureTags = append(featureTags, "playwright") }
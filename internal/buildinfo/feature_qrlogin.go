@@ -0,0 +1,5 @@
+//go:build qrlogin
+
+package buildinfo
+
+func init() { featureTags = append(featureTags, "qrlogin") }
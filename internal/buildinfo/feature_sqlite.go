@@ -0,0 +1,5 @@
+//go:build sqlite
+
+package buildinfo
+
+func init() { featureTags = append(featureTags, "sqlite") }
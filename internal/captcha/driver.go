@@ -0,0 +1,132 @@
+package captcha
+
+import (
+	"context"
+	"fmt"
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+)
+
+// Attempt describes one escalation step of the retry driver: the Pipeline
+// to clean the image with (more aggressive stages at later attempts) and
+// whether to hand Pipeline.Run's per-character crops to the Solver instead
+// of the whole cleaned image.
+type Attempt struct {
+	Pipeline     *Pipeline
+	PerCharacter bool
+}
+
+// Driver runs an image through successively more aggressive Attempts,
+// calling Solver.Solve after each, until one clears ConfidenceThreshold or
+// the attempts are exhausted. It optionally logs the image the final
+// attempt produced to SampleDir, labeled with the solved text and whether
+// the result was accepted, for later use as OCR training data.
+type Driver struct {
+	Attempts            []Attempt
+	Solver              Solver
+	ConfidenceThreshold float64
+
+	// SampleDir, if set, receives one labeled PNG per Solve call.
+	SampleDir string
+}
+
+// Solve runs raw through each Attempt's Pipeline in order, calling Solver
+// after each, and returns the first result whose confidence reaches
+// ConfidenceThreshold. If every attempt falls short, it returns the
+// highest-confidence result seen along with a non-nil error so callers can
+// decide whether to use it anyway or give up.
+func (d *Driver) Solve(ctx context.Context, raw image.Image, hint Hint) (string, float64, error) {
+	var bestText string
+	var bestConfidence float64
+	var lastImage image.Image
+	var lastText string
+
+	for i, attempt := range d.Attempts {
+		result, err := attempt.Pipeline.Run(raw)
+		if err != nil {
+			return "", 0, fmt.Errorf("captcha: attempt %d: %w", i, err)
+		}
+
+		text, confidence, err := d.solveAttempt(ctx, result, attempt, hint)
+		if err != nil {
+			return "", 0, fmt.Errorf("captcha: attempt %d solve: %w", i, err)
+		}
+
+		accepted := confidence >= d.ConfidenceThreshold
+
+		if confidence > bestConfidence {
+			bestText, bestConfidence = text, confidence
+		}
+		if accepted {
+			d.logSample(result.Image, text, true)
+			return text, confidence, nil
+		}
+		lastImage, lastText = result.Image, text
+	}
+
+	if lastImage != nil {
+		d.logSample(lastImage, lastText, false)
+	}
+	return bestText, bestConfidence, fmt.Errorf("captcha: no attempt reached confidence threshold %.2f (best %.2f)", d.ConfidenceThreshold, bestConfidence)
+}
+
+// solveAttempt solves either the whole cleaned image or, for
+// PerCharacter attempts, each character crop in turn, concatenating the
+// results and averaging confidence across characters.
+func (d *Driver) solveAttempt(ctx context.Context, result Result, attempt Attempt, hint Hint) (string, float64, error) {
+	if !attempt.PerCharacter || len(result.Chars) == 0 {
+		return d.Solver.Solve(ctx, result.Image, hint)
+	}
+
+	var text string
+	var confidenceSum float64
+	for _, char := range result.Chars {
+		charHint := hint
+		charHint.Length = 1
+		charText, confidence, err := d.Solver.Solve(ctx, char, charHint)
+		if err != nil {
+			return "", 0, err
+		}
+		text += charText
+		confidenceSum += confidence
+	}
+	return text, confidenceSum / float64(len(result.Chars)), nil
+}
+
+// logSample writes img to SampleDir as a labeled PNG. Failures are ignored
+// beyond logging to stderr — sample collection is a best-effort side
+// channel, not something that should fail a Solve call.
+func (d *Driver) logSample(img image.Image, label string, accepted bool) {
+	if d.SampleDir == "" {
+		return
+	}
+
+	status := "rejected"
+	if accepted {
+		status = "accepted"
+	}
+	if label == "" {
+		label = "unknown"
+	}
+
+	if err := os.MkdirAll(d.SampleDir, 0o755); err != nil {
+		fmt.Fprintf(os.Stderr, "captcha: creating sample dir %s: %v\n", d.SampleDir, err)
+		return
+	}
+
+	name := fmt.Sprintf("%s_%s.png", status, label)
+	path := filepath.Join(d.SampleDir, name)
+
+	f, err := os.Create(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "captcha: creating sample file %s: %v\n", path, err)
+		return
+	}
+	defer f.Close()
+
+	if err := png.Encode(f, img); err != nil {
+		fmt.Fprintf(os.Stderr, "captcha: encoding sample file %s: %v\n", path, err)
+	}
+}
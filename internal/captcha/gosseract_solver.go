@@ -0,0 +1,73 @@
+package captcha
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"image/png"
+	"strings"
+
+	"github.com/otiai10/gosseract/v2"
+)
+
+// GosseractSolver runs OCR locally via Tesseract (through the gosseract
+// cgo bindings). It's the default, no-external-dependency Solver; for
+// CAPTCHAs too distorted for Tesseract, HTTPSolver or ManualSolver can be
+// used instead. Driver (see driver.go) takes a single Solver; this package
+// has no solver that chains several together.
+type GosseractSolver struct{}
+
+// Solve encodes img as PNG, hands it to a fresh gosseract.Client per call
+// (the client isn't safe to share across concurrent Solve calls — see
+// gosseract's own docs — so this trades a small per-call setup cost for
+// not needing a pool), applies hint.Whitelist if set, and reports
+// confidence as the mean of gosseract's per-word confidences. The
+// underlying Tesseract C++ API exposes this as TessBaseAPI's
+// AllWordConfidences; gosseract's Go wrapper surfaces the same data via
+// GetBoundingBoxesVerbose, which is what this calls.
+func (GosseractSolver) Solve(ctx context.Context, img image.Image, hint Hint) (string, float64, error) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return "", 0, fmt.Errorf("captcha: encoding image for gosseract: %w", err)
+	}
+
+	client := gosseract.NewClient()
+	defer client.Close()
+
+	if hint.Whitelist != "" {
+		if err := client.SetWhitelist(hint.Whitelist); err != nil {
+			return "", 0, fmt.Errorf("captcha: setting gosseract whitelist: %w", err)
+		}
+	}
+
+	if err := client.SetImageFromBytes(buf.Bytes()); err != nil {
+		return "", 0, fmt.Errorf("captcha: loading image into gosseract: %w", err)
+	}
+
+	boxes, err := client.GetBoundingBoxesVerbose()
+	if err != nil {
+		return "", 0, fmt.Errorf("captcha: gosseract recognition: %w", err)
+	}
+
+	var words []string
+	var confidenceSum float64
+	for _, box := range boxes {
+		word := strings.TrimSpace(box.Word)
+		if word == "" {
+			continue
+		}
+		words = append(words, word)
+		confidenceSum += box.Confidence
+	}
+
+	if len(words) == 0 {
+		return "", 0, nil
+	}
+
+	text := strings.Join(words, "")
+	// Tesseract reports confidence on a 0-100 scale; Solver's contract is
+	// 0-1.
+	confidence := confidenceSum / float64(len(words)) / 100
+	return text, confidence, nil
+}
@@ -0,0 +1,149 @@
+package captcha
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/png"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// HTTPSolver adapts a 2Captcha/Anti-Captcha-style JSON API: submit a
+// base64-encoded image, get back a job ID, then poll for the solved text.
+// Both services (and most compatible ones) share this submit-then-poll
+// shape, hence the configurable SubmitURL/PollURL below. The request and
+// response field names are fixed to 2Captcha's (key/method/body/json on
+// submit, status/request in responses); they are not configurable.
+type HTTPSolver struct {
+	// SubmitURL and PollURL are the two endpoints, e.g.
+	// "https://2captcha.com/in.php" and "https://2captcha.com/res.php".
+	SubmitURL string
+	PollURL   string
+	APIKey    string
+
+	Client       *http.Client
+	PollInterval time.Duration // default 5s
+	PollTimeout  time.Duration // default 2m
+}
+
+type submitResponse struct {
+	Status  int    `json:"status"`
+	Request string `json:"request"`
+}
+
+type pollResponse struct {
+	Status  int    `json:"status"`
+	Request string `json:"request"`
+}
+
+// Solve submits img as a base64 body to SubmitURL, then polls PollURL until
+// the job completes, fails, or PollTimeout elapses. Confidence is always 1
+// on success (the upstream service doesn't report one) or 0 on failure.
+func (h HTTPSolver) Solve(ctx context.Context, img image.Image, hint Hint) (string, float64, error) {
+	client := h.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	pollInterval := h.PollInterval
+	if pollInterval <= 0 {
+		pollInterval = 5 * time.Second
+	}
+	pollTimeout := h.PollTimeout
+	if pollTimeout <= 0 {
+		pollTimeout = 2 * time.Minute
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return "", 0, fmt.Errorf("captcha: encoding image for HTTP solver: %w", err)
+	}
+	encoded := base64.StdEncoding.EncodeToString(buf.Bytes())
+
+	jobID, err := h.submit(ctx, client, encoded, hint)
+	if err != nil {
+		return "", 0, err
+	}
+
+	deadline := time.Now().Add(pollTimeout)
+	for time.Now().Before(deadline) {
+		select {
+		case <-ctx.Done():
+			return "", 0, ctx.Err()
+		case <-time.After(pollInterval):
+		}
+
+		text, ready, err := h.poll(ctx, client, jobID)
+		if err != nil {
+			return "", 0, err
+		}
+		if ready {
+			return text, 1, nil
+		}
+	}
+	return "", 0, fmt.Errorf("captcha: HTTP solver job %s timed out after %v", jobID, pollTimeout)
+}
+
+func (h HTTPSolver) submit(ctx context.Context, client *http.Client, base64Body string, hint Hint) (string, error) {
+	form := url.Values{
+		"key":    {h.APIKey},
+		"method": {"base64"},
+		"body":   {base64Body},
+		"json":   {"1"},
+	}
+	if hint.Length > 0 {
+		form.Set("textinstructions", fmt.Sprintf("%d characters", hint.Length))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, h.SubmitURL, bytes.NewBufferString(form.Encode()))
+	if err != nil {
+		return "", fmt.Errorf("captcha: building submit request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("captcha: submitting to HTTP solver: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var parsed submitResponse
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return "", fmt.Errorf("captcha: parsing submit response: %w", err)
+	}
+	if parsed.Status != 1 {
+		return "", fmt.Errorf("captcha: HTTP solver rejected submission: %s", parsed.Request)
+	}
+	return parsed.Request, nil
+}
+
+func (h HTTPSolver) poll(ctx context.Context, client *http.Client, jobID string) (text string, ready bool, err error) {
+	u := fmt.Sprintf("%s?key=%s&action=get&id=%s&json=1", h.PollURL, url.QueryEscape(h.APIKey), url.QueryEscape(jobID))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return "", false, fmt.Errorf("captcha: building poll request: %w", err)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", false, fmt.Errorf("captcha: polling HTTP solver: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var parsed pollResponse
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return "", false, fmt.Errorf("captcha: parsing poll response: %w", err)
+	}
+
+	if parsed.Status == 1 {
+		return parsed.Request, true, nil
+	}
+	if parsed.Request == "CAPCHA_NOT_READY" {
+		return "", false, nil
+	}
+	return "", false, fmt.Errorf("captcha: HTTP solver job %s failed: %s", jobID, parsed.Request)
+}
@@ -0,0 +1,36 @@
+package captcha
+
+import (
+	"context"
+	"fmt"
+	"image"
+)
+
+// Prompter displays img to a human operator and blocks until they type an
+// answer. This repository has no implementation of it; whoever builds a
+// ManualSolver supplies one. It is an interface so that this package never
+// has to import a UI package such as internal/gui — captcha is used from
+// internal/services, which gui already imports, and gui importing captcha
+// back would be fine, but captcha importing gui would create a cycle.
+type Prompter interface {
+	Prompt(ctx context.Context, img image.Image, hint Hint) (string, error)
+}
+
+// ManualSolver falls back to a human operator when automated solvers can't
+// clear the confidence threshold. There's no machine confidence signal for
+// a manually typed answer, so Solve always reports 1 on success.
+type ManualSolver struct {
+	Prompter Prompter
+}
+
+func (m ManualSolver) Solve(ctx context.Context, img image.Image, hint Hint) (string, float64, error) {
+	if m.Prompter == nil {
+		return "", 0, fmt.Errorf("captcha: manual solver has no Prompter configured")
+	}
+
+	text, err := m.Prompter.Prompt(ctx, img, hint)
+	if err != nil {
+		return "", 0, fmt.Errorf("captcha: manual prompt: %w", err)
+	}
+	return text, 1, nil
+}
@@ -0,0 +1,71 @@
+// Package captcha cleans up noisy CAPTCHA screenshots before OCR and
+// dispatches the cleaned image to a pluggable Solver — local Tesseract
+// (gosseract_solver.go), an HTTP solving service like 2Captcha/Anti-Captcha
+// (http_solver.go), or a human operator via the GUI (manual_solver.go).
+// driver.go ties a Pipeline and a Solver together with a confidence-gated
+// retry loop, escalating through more aggressive stages as attempts fail.
+package captcha
+
+import (
+	"fmt"
+	"image"
+)
+
+// Stage transforms one image into another, e.g. grayscale conversion or
+// deskewing. Stages are composed in order by Pipeline.Run.
+type Stage func(img image.Image) (image.Image, error)
+
+// SegmentStage splits a cleaned image into per-character crops, for
+// solvers (or whitelisted OCR passes) that do better character-by-character
+// than on the whole string at once.
+type SegmentStage func(img image.Image) ([]image.Image, error)
+
+// Result is what a Pipeline produces: the fully cleaned image, plus
+// per-character crops if the pipeline had a SegmentStage.
+type Result struct {
+	Image image.Image
+	Chars []image.Image // nil unless the pipeline included SegmentChars
+}
+
+// Pipeline runs an ordered list of Stages over an image, then optionally a
+// SegmentStage over the result.
+type Pipeline struct {
+	Stages  []Stage
+	Segment SegmentStage // optional; set via SegmentChars
+}
+
+// NewPipeline returns a Pipeline that runs stages in order.
+func NewPipeline(stages ...Stage) *Pipeline {
+	return &Pipeline{Stages: stages}
+}
+
+// Run applies every stage in order, then the segment stage if set.
+func (p *Pipeline) Run(img image.Image) (Result, error) {
+	current := img
+	for i, stage := range p.Stages {
+		next, err := stage(current)
+		if err != nil {
+			return Result{}, fmt.Errorf("captcha: pipeline stage %d: %w", i, err)
+		}
+		current = next
+	}
+
+	result := Result{Image: current}
+	if p.Segment != nil {
+		chars, err := p.Segment(current)
+		if err != nil {
+			return Result{}, fmt.Errorf("captcha: segment stage: %w", err)
+		}
+		result.Chars = chars
+	}
+	return result, nil
+}
+
+// WithSegmentChars returns a copy of p with its SegmentStage set to
+// SegmentChars(minRunWidth), for call sites that build a pipeline
+// declaratively: captcha.NewPipeline(Grayscale(), ...).WithSegmentChars(4).
+func (p *Pipeline) WithSegmentChars(minRunWidth int) *Pipeline {
+	clone := *p
+	clone.Segment = SegmentChars(minRunWidth)
+	return &clone
+}
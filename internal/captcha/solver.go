@@ -0,0 +1,27 @@
+package captcha
+
+import (
+	"context"
+	"image"
+)
+
+// Hint carries optional, solver-specific guidance about the CAPTCHA being
+// solved. A Solver is free to ignore any field it doesn't use.
+type Hint struct {
+	// Length is the expected character count, if known (0 means unknown).
+	Length int
+	// Whitelist restricts recognized characters, e.g. "0123456789" for a
+	// digits-only CAPTCHA. Empty means no restriction.
+	Whitelist string
+	// SiteTag identifies the vendor/site this CAPTCHA came from, for
+	// solvers (or logs) that track per-site accuracy.
+	SiteTag string
+}
+
+// Solver recognizes text in img and reports a confidence in [0,1]. A
+// confidence of 0 with a nil error means "ran, but isn't sure" rather than
+// a hard failure — callers should treat it as a low-confidence result, not
+// retry-on-error.
+type Solver interface {
+	Solve(ctx context.Context, img image.Image, hint Hint) (text string, confidence float64, err error)
+}
@@ -0,0 +1,498 @@
+package captcha
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+
+	"github.com/disintegration/imaging"
+)
+
+// Grayscale converts to 8-bit grayscale, the expected input for every
+// other stage in this package.
+func Grayscale() Stage {
+	return func(img image.Image) (image.Image, error) {
+		return imaging.Grayscale(img), nil
+	}
+}
+
+// ThresholdMode selects how Threshold picks its binarization cutoff.
+type ThresholdMode int
+
+const (
+	// ThresholdOtsu picks a single global cutoff maximizing between-class
+	// variance over the image's luma histogram.
+	ThresholdOtsu ThresholdMode = iota
+	// ThresholdAdaptive picks a local cutoff per pixel from the mean of an
+	// NxN neighborhood, which holds up better against uneven lighting
+	// across a CAPTCHA image than one global cutoff.
+	ThresholdAdaptive
+)
+
+// Threshold binarizes a (grayscale) image to pure black/white.
+func Threshold(mode ThresholdMode) Stage {
+	return func(img image.Image) (image.Image, error) {
+		gray := toGray(img)
+		switch mode {
+		case ThresholdAdaptive:
+			return adaptiveThreshold(gray, 15, 10), nil
+		default:
+			t := otsuThreshold(gray)
+			return applyThreshold(gray, t), nil
+		}
+	}
+}
+
+// otsuThreshold computes the 256-bin luma histogram and returns the
+// threshold t in [0,255] maximizing the between-class variance
+// w0*w1*(mu0-mu1)^2 between pixels below and at-or-above t.
+func otsuThreshold(gray *image.Gray) int {
+	var histogram [256]int
+	for _, v := range gray.Pix {
+		histogram[v]++
+	}
+
+	total := len(gray.Pix)
+	var sumAll float64
+	for i, count := range histogram {
+		sumAll += float64(i * count)
+	}
+
+	var sumB, weightB float64
+	var bestT int
+	var bestVariance float64
+
+	for t := 0; t < 256; t++ {
+		weightB += float64(histogram[t])
+		if weightB == 0 {
+			continue
+		}
+		weightF := float64(total) - weightB
+		if weightF == 0 {
+			break
+		}
+
+		sumB += float64(t * histogram[t])
+		meanB := sumB / weightB
+		meanF := (sumAll - sumB) / weightF
+
+		variance := weightB * weightF * (meanB - meanF) * (meanB - meanF)
+		if variance > bestVariance {
+			bestVariance = variance
+			// The background class here is every pixel <= t, i.e. below t+1.
+			bestT = t + 1
+		}
+	}
+	return bestT
+}
+
+// applyThreshold maps every pixel below t to black, everything else to
+// white.
+func applyThreshold(gray *image.Gray, t int) *image.Gray {
+	out := image.NewGray(gray.Bounds())
+	for i, v := range gray.Pix {
+		if int(v) < t {
+			out.Pix[i] = 0
+		} else {
+			out.Pix[i] = 255
+		}
+	}
+	return out
+}
+
+// adaptiveThreshold binarizes each pixel against the mean of its
+// windowSize x windowSize neighborhood minus bias, so uneven lighting
+// across the image doesn't blow out one side while crushing the other the
+// way a single global Otsu cutoff can.
+func adaptiveThreshold(gray *image.Gray, windowSize, bias int) *image.Gray {
+	bounds := gray.Bounds()
+	out := image.NewGray(bounds)
+	half := windowSize / 2
+
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			var sum, count int
+			for wy := y - half; wy <= y+half; wy++ {
+				if wy < bounds.Min.Y || wy >= bounds.Max.Y {
+					continue
+				}
+				for wx := x - half; wx <= x+half; wx++ {
+					if wx < bounds.Min.X || wx >= bounds.Max.X {
+						continue
+					}
+					sum += int(gray.GrayAt(wx, wy).Y)
+					count++
+				}
+			}
+			mean := sum / count
+			v := gray.GrayAt(x, y).Y
+			if int(v) < mean-bias {
+				out.SetGray(x, y, color.Gray{Y: 0})
+			} else {
+				out.SetGray(x, y, color.Gray{Y: 255})
+			}
+		}
+	}
+	return out
+}
+
+// Denoise applies a k x k median filter, which removes salt-and-pepper
+// speckle noise without blurring character edges the way a mean/Gaussian
+// blur would.
+func Denoise(k int) Stage {
+	return func(img image.Image) (image.Image, error) {
+		if k < 3 || k%2 == 0 {
+			return nil, fmt.Errorf("captcha: Denoise kernel size must be odd and >= 3, got %d", k)
+		}
+		return medianFilter(toGray(img), k), nil
+	}
+}
+
+func medianFilter(gray *image.Gray, k int) *image.Gray {
+	bounds := gray.Bounds()
+	out := image.NewGray(bounds)
+	half := k / 2
+	window := make([]byte, 0, k*k)
+
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			window = window[:0]
+			for wy := y - half; wy <= y+half; wy++ {
+				cy := clampInt(wy, bounds.Min.Y, bounds.Max.Y-1)
+				for wx := x - half; wx <= x+half; wx++ {
+					cx := clampInt(wx, bounds.Min.X, bounds.Max.X-1)
+					window = append(window, gray.GrayAt(cx, cy).Y)
+				}
+			}
+			out.SetGray(x, y, color.Gray{Y: medianByte(window)})
+		}
+	}
+	return out
+}
+
+func medianByte(values []byte) byte {
+	sorted := append([]byte(nil), values...)
+	insertionSortBytes(sorted)
+	return sorted[len(sorted)/2]
+}
+
+// insertionSortBytes sorts small fixed-size windows (typically 9-49
+// elements for k in [3,7]) in place; insertion sort beats the overhead of
+// sort.Slice at this size and avoids the allocation of a sort.Interface.
+func insertionSortBytes(values []byte) {
+	for i := 1; i < len(values); i++ {
+		v := values[i]
+		j := i - 1
+		for j >= 0 && values[j] > v {
+			values[j+1] = values[j]
+			j--
+		}
+		values[j+1] = v
+	}
+}
+
+func clampInt(v, lo, hi int) int {
+	if v < lo {
+		return lo
+	}
+	if v > hi {
+		return hi
+	}
+	return v
+}
+
+// Deskew estimates the image's rotation by projecting pixel-darkness sums
+// onto rows at several candidate angles in [-15,15] degrees and picking the
+// angle that maximizes the variance of those row sums (text aligned with
+// the rows produces sharp dark bands; misaligned text smears them flat),
+// then rotates by the negative of that angle to straighten it.
+func Deskew() Stage {
+	return func(img image.Image) (image.Image, error) {
+		gray := toGray(img)
+		angle := bestSkewAngle(gray)
+		if angle == 0 {
+			return gray, nil
+		}
+		return imaging.Rotate(gray, -angle, color.White), nil
+	}
+}
+
+func bestSkewAngle(gray *image.Gray) float64 {
+	const (
+		maxAngle  = 15.0
+		stepAngle = 0.5
+	)
+
+	var bestAngle float64
+	var bestVariance float64
+
+	for angle := -maxAngle; angle <= maxAngle; angle += stepAngle {
+		rotated := gray
+		if angle != 0 {
+			// Rows at +angle line up with the image's rows after rotating by
+			// -angle, so Deskew's rotation by -angle is the one scored here.
+			rotated = toGray(imaging.Rotate(gray, -angle, color.White))
+		}
+		variance := rowSumVariance(rotated)
+		if variance > bestVariance {
+			bestVariance = variance
+			bestAngle = angle
+		}
+	}
+	return bestAngle
+}
+
+func rowSumVariance(gray *image.Gray) float64 {
+	bounds := gray.Bounds()
+	height := bounds.Dy()
+	if height == 0 {
+		return 0
+	}
+
+	sums := make([]float64, height)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		var sum float64
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			sum += 255 - float64(gray.GrayAt(x, y).Y) // darkness, not brightness
+		}
+		sums[y-bounds.Min.Y] = sum
+	}
+
+	var mean float64
+	for _, s := range sums {
+		mean += s
+	}
+	mean /= float64(height)
+
+	var variance float64
+	for _, s := range sums {
+		variance += (s - mean) * (s - mean)
+	}
+	return variance / float64(height)
+}
+
+// Deslant corrects italic-style slant (as opposed to whole-image rotation,
+// which Deskew handles) by shearing the image horizontally by an amount
+// proportional to each row's distance from vertical center, testing a
+// small range of shear factors and keeping the one that minimizes the
+// image's horizontal bounding width — a slanted glyph's ink spreads wider
+// horizontally than an upright one.
+func Deslant() Stage {
+	return func(img image.Image) (image.Image, error) {
+		gray := toGray(img)
+		factor := bestShearFactor(gray)
+		if factor == 0 {
+			return gray, nil
+		}
+		return shearHorizontal(gray, factor), nil
+	}
+}
+
+func bestShearFactor(gray *image.Gray) float64 {
+	const (
+		maxShear  = 0.5
+		stepShear = 0.05
+	)
+
+	var bestFactor float64
+	bestWidth := inkWidth(gray)
+
+	for factor := -maxShear; factor <= maxShear; factor += stepShear {
+		if factor == 0 {
+			continue
+		}
+		width := inkWidth(shearHorizontal(gray, factor))
+		if width < bestWidth {
+			bestWidth = width
+			bestFactor = factor
+		}
+	}
+	return bestFactor
+}
+
+// inkWidth returns the horizontal span, in pixels, between the leftmost
+// and rightmost dark (below mid-gray) pixel in the image.
+func inkWidth(gray *image.Gray) int {
+	bounds := gray.Bounds()
+	minX, maxX := bounds.Max.X, bounds.Min.X
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			if gray.GrayAt(x, y).Y < 128 {
+				if x < minX {
+					minX = x
+				}
+				if x > maxX {
+					maxX = x
+				}
+			}
+		}
+	}
+	if maxX < minX {
+		return 0
+	}
+	return maxX - minX
+}
+
+// shearHorizontal shifts row y by factor*(y-centerY) pixels, filling
+// uncovered area with white.
+func shearHorizontal(gray *image.Gray, factor float64) *image.Gray {
+	bounds := gray.Bounds()
+	out := image.NewGray(bounds)
+	for i := range out.Pix {
+		out.Pix[i] = 255
+	}
+
+	centerY := float64(bounds.Min.Y+bounds.Max.Y) / 2
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		shift := int(math.Round(factor * (float64(y) - centerY)))
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			sx := x + shift
+			if sx < bounds.Min.X || sx >= bounds.Max.X {
+				continue
+			}
+			out.SetGray(sx, y, gray.GrayAt(x, y))
+		}
+	}
+	return out
+}
+
+// RemoveLines erases straight lines crossing the image — the
+// horizontal/vertical noise strokes many CAPTCHA generators draw through
+// the text. This is a simplified, axis-aligned relative of a full Hough
+// transform: instead of accumulating votes in (angle, offset) space for
+// arbitrary lines, it looks only for near-horizontal and near-vertical
+// runs (the overwhelming majority of CAPTCHA noise lines) by scanning rows
+// and columns whose dark-pixel fraction exceeds threshold, and whites them
+// out. A true Hough transform would also catch diagonal lines, at several
+// times the cost, which this package doesn't currently need.
+func RemoveLines(threshold float64) Stage {
+	return func(img image.Image) (image.Image, error) {
+		gray := toGray(img)
+		out := cloneGray(gray)
+		bounds := gray.Bounds()
+
+		for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+			if darkFraction(gray, 0, y, bounds.Dx(), 1) >= threshold {
+				whiteOutRow(out, y)
+			}
+		}
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			if darkFraction(gray, x, 0, 1, bounds.Dy()) >= threshold {
+				whiteOutColumn(out, x)
+			}
+		}
+		return out, nil
+	}
+}
+
+func darkFraction(gray *image.Gray, x, y, w, h int) float64 {
+	bounds := gray.Bounds()
+	var dark, total int
+	for dy := 0; dy < h; dy++ {
+		for dx := 0; dx < w; dx++ {
+			px, py := bounds.Min.X+x+dx, bounds.Min.Y+y+dy
+			if px < bounds.Min.X || px >= bounds.Max.X || py < bounds.Min.Y || py >= bounds.Max.Y {
+				continue
+			}
+			total++
+			if gray.GrayAt(px, py).Y < 128 {
+				dark++
+			}
+		}
+	}
+	if total == 0 {
+		return 0
+	}
+	return float64(dark) / float64(total)
+}
+
+func whiteOutRow(gray *image.Gray, y int) {
+	bounds := gray.Bounds()
+	for x := bounds.Min.X; x < bounds.Max.X; x++ {
+		gray.SetGray(x, y, color.Gray{Y: 255})
+	}
+}
+
+func whiteOutColumn(gray *image.Gray, x int) {
+	bounds := gray.Bounds()
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		gray.SetGray(x, y, color.Gray{Y: 255})
+	}
+}
+
+// Upscale resizes the image by factor using Lanczos resampling, which
+// Tesseract's character recognition tends to do noticeably better against
+// than the tiny (often <100px tall) crops CAPTCHA images ship as.
+func Upscale(factor float64) Stage {
+	return func(img image.Image) (image.Image, error) {
+		if factor <= 0 {
+			return nil, fmt.Errorf("captcha: Upscale factor must be positive, got %v", factor)
+		}
+		bounds := img.Bounds()
+		width := int(math.Round(float64(bounds.Dx()) * factor))
+		height := int(math.Round(float64(bounds.Dy()) * factor))
+		return imaging.Resize(img, width, height, imaging.Lanczos), nil
+	}
+}
+
+// SegmentChars splits a cleaned (binarized) image into per-character crops
+// by finding vertical whitespace gaps: it scans columns for ones that are
+// entirely white (a single dark pixel makes a column ink) and treats
+// contiguous non-whitespace runs of
+// at least minRunWidth pixels as one character.
+func SegmentChars(minRunWidth int) SegmentStage {
+	return func(img image.Image) ([]image.Image, error) {
+		gray := toGray(img)
+		bounds := gray.Bounds()
+
+		var runs [][2]int // [startX, endX) in absolute coordinates
+		inRun := false
+		runStart := bounds.Min.X
+
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			hasInk := darkFraction(gray, x-bounds.Min.X, 0, 1, bounds.Dy()) > 0
+			switch {
+			case hasInk && !inRun:
+				inRun = true
+				runStart = x
+			case !hasInk && inRun:
+				inRun = false
+				if x-runStart >= minRunWidth {
+					runs = append(runs, [2]int{runStart, x})
+				}
+			}
+		}
+		if inRun && bounds.Max.X-runStart >= minRunWidth {
+			runs = append(runs, [2]int{runStart, bounds.Max.X})
+		}
+
+		chars := make([]image.Image, 0, len(runs))
+		for _, run := range runs {
+			crop := image.Rect(run[0], bounds.Min.Y, run[1], bounds.Max.Y)
+			chars = append(chars, imaging.Crop(gray, crop))
+		}
+		return chars, nil
+	}
+}
+
+// toGray converts any image.Image to *image.Gray. imaging.Grayscale (used
+// by the Grayscale stage) returns an *image.NRGBA with equal channels
+// rather than a true *image.Gray, so every stage after it still needs this
+// conversion before doing per-pixel math.
+func toGray(img image.Image) *image.Gray {
+	if g, ok := img.(*image.Gray); ok {
+		return g
+	}
+	bounds := img.Bounds()
+	gray := image.NewGray(bounds)
+	draw.Draw(gray, bounds, img, bounds.Min, draw.Src)
+	return gray
+}
+
+func cloneGray(gray *image.Gray) *image.Gray {
+	out := image.NewGray(gray.Bounds())
+	copy(out.Pix, gray.Pix)
+	return out
+}
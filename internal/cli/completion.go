@@ -0,0 +1,102 @@
+package cli
+
+import "fmt"
+
+// Completion renders a shell completion script for the named shell, listing
+// cmdNames (typically command.Registry.CommandNames(), plus "help") as the
+// static subcommand completions. Every script also shells out to `<bin>
+// list` for dynamic completion of run-bot's argument, so newly added/
+// renamed bots show up without regenerating anything. binName is the
+// invoked binary name (e.g. "tix-scraper" or "tix-scraper-cli"), used both
+// as the function name and the `complete` target.
+func Completion(shell string, binName string, cmdNames []string) (string, error) {
+	switch shell {
+	case "bash":
+		return bashCompletion(binName, cmdNames), nil
+	case "zsh":
+		return zshCompletion(binName, cmdNames), nil
+	case "fish":
+		return fishCompletion(binName, cmdNames), nil
+	case "powershell":
+		return powershellCompletion(binName, cmdNames), nil
+	default:
+		return "", fmt.Errorf("completion: unsupported shell %q (use bash, zsh, fish, or powershell)", shell)
+	}
+}
+
+func bashCompletion(bin string, cmdNames []string) string {
+	return fmt.Sprintf(`# bash completion for %[1]s
+_%[1]s_completions() {
+    local cur prev cmds
+    cur="${COMP_WORDS[COMP_CWORD]}"
+    prev="${COMP_WORDS[COMP_CWORD-1]}"
+    cmds="%[2]s"
+
+    if [[ "$prev" == "run-bot" ]]; then
+        COMPREPLY=($(compgen -W "$(%[1]s list 2>/dev/null | cut -d: -f1)" -- "$cur"))
+        return
+    fi
+
+    COMPREPLY=($(compgen -W "$cmds" -- "$cur"))
+}
+complete -F _%[1]s_completions %[1]s
+`, bin, joinNames(cmdNames))
+}
+
+func zshCompletion(bin string, cmdNames []string) string {
+	return fmt.Sprintf(`#compdef %[1]s
+
+_%[1]s() {
+    local -a cmds
+    cmds=(%[2]s)
+
+    if (( CURRENT == 3 )) && [[ ${words[2]} == "run-bot" ]]; then
+        local -a bots
+        bots=("${(@f)$(%[1]s list 2>/dev/null | cut -d: -f1)}")
+        _describe 'bot' bots
+        return
+    fi
+
+    _describe 'command' cmds
+}
+
+_%[1]s
+`, bin, joinNames(cmdNames))
+}
+
+func fishCompletion(bin string, cmdNames []string) string {
+	return fmt.Sprintf(`# fish completion for %[1]s
+complete -c %[1]s -f
+complete -c %[1]s -n "__fish_use_subcommand" -a "%[2]s"
+complete -c %[1]s -n "__fish_seen_subcommand_from run-bot" -a "(%[1]s list 2>/dev/null | string split ':' -f1)"
+`, bin, joinNames(cmdNames))
+}
+
+func powershellCompletion(bin string, cmdNames []string) string {
+	return fmt.Sprintf(`# PowerShell completion for %[1]s
+Register-ArgumentCompleter -Native -CommandName %[1]s -ScriptBlock {
+    param($wordToComplete, $commandAst, $cursorPosition)
+
+    $tokens = $commandAst.CommandElements | ForEach-Object { $_.ToString() }
+    if ($tokens.Count -ge 2 -and $tokens[1] -eq "run-bot") {
+        & %[1]s list 2>$null | ForEach-Object { ($_ -split ":")[0] } | Where-Object { $_ -like "$wordToComplete*" } |
+            ForEach-Object { [System.Management.Automation.CompletionResult]::new($_, $_, 'ParameterValue', $_) }
+        return
+    }
+
+    "%[2]s" -split ' ' | Where-Object { $_ -like "$wordToComplete*" } |
+        ForEach-Object { [System.Management.Automation.CompletionResult]::new($_, $_, 'ParameterValue', $_) }
+}
+`, bin, joinNames(cmdNames))
+}
+
+func joinNames(names []string) string {
+	out := ""
+	for i, n := range names {
+		if i > 0 {
+			out += " "
+		}
+		out += n
+	}
+	return out
+}
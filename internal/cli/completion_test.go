@@ -0,0 +1,44 @@
+package cli
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var updateGolden = flag.Bool("update", false, "update golden files in testdata/completion")
+
+var testCmdNames = []string{"run", "list", "run-bot", "help"}
+
+func TestCompletionGolden(t *testing.T) {
+	for _, shell := range []string{"bash", "zsh", "fish", "powershell"} {
+		t.Run(shell, func(t *testing.T) {
+			got, err := Completion(shell, "tix-scraper", testCmdNames)
+			if err != nil {
+				t.Fatalf("Completion(%q): %v", shell, err)
+			}
+
+			golden := filepath.Join("testdata", "completion", shell+".txt")
+			if *updateGolden {
+				if err := os.WriteFile(golden, []byte(got), 0o644); err != nil {
+					t.Fatalf("writing golden file: %v", err)
+				}
+			}
+
+			want, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatalf("reading golden file: %v", err)
+			}
+			if got != string(want) {
+				t.Errorf("Completion(%q) mismatch (run with -update to refresh)\ngot:\n%s\nwant:\n%s", shell, got, want)
+			}
+		})
+	}
+}
+
+func TestCompletionUnsupportedShell(t *testing.T) {
+	if _, err := Completion("tcsh", "tix-scraper", testCmdNames); err == nil {
+		t.Fatal("Completion(\"tcsh\", ...): expected error, got nil")
+	}
+}
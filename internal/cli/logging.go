@@ -0,0 +1,49 @@
+package cli
+
+import (
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+)
+
+// newHandler builds a slog.Handler writing to w in the requested format
+// ("json" or anything else, which falls back to text).
+func newHandler(format string, w io.Writer) slog.Handler {
+	if format == "json" {
+		return slog.NewJSONHandler(w, nil)
+	}
+	return slog.NewTextHandler(w, nil)
+}
+
+// newBotLogger returns a logger scoped to cfg, carrying bot_id, bot_name,
+// event_id, and ticket_id as structured fields. When cfg.LogDir is set, its
+// output is tee'd to <LogDir>/<bot-id>.log in addition to stdout; the
+// returned close func must be called once the bot is done logging.
+func newBotLogger(format string, cfg BotConfig) (*slog.Logger, func()) {
+	writer := io.Writer(os.Stdout)
+	closeFn := func() {}
+
+	if cfg.LogDir != "" {
+		if err := os.MkdirAll(cfg.LogDir, 0755); err != nil {
+			slog.Error("failed to create bot log directory", "log_dir", cfg.LogDir, "error", err)
+		} else {
+			path := filepath.Join(cfg.LogDir, cfg.ID+".log")
+			f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+			if err != nil {
+				slog.Error("failed to open bot log file", "path", path, "error", err)
+			} else {
+				writer = io.MultiWriter(os.Stdout, f)
+				closeFn = func() { f.Close() }
+			}
+		}
+	}
+
+	logger := slog.New(newHandler(format, writer)).With(
+		"bot_id", cfg.ID,
+		"bot_name", cfg.Name,
+		"event_id", cfg.EventID,
+		"ticket_id", cfg.TicketID,
+	)
+	return logger, closeFn
+}
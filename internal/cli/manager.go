@@ -0,0 +1,351 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// BotState describes where a managed bot is in its lifecycle.
+type BotState string
+
+const (
+	StatePending BotState = "pending-schedule"
+	StateRunning BotState = "running"
+	StateFailed  BotState = "failed"
+	StateStopped BotState = "stopped"
+)
+
+// managedBot tracks the runtime state of a single configured bot.
+type managedBot struct {
+	mu     sync.Mutex
+	config BotConfig
+	state  BotState
+	cancel context.CancelFunc
+}
+
+func (b *managedBot) snapshot() (BotConfig, BotState) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.config, b.state
+}
+
+func (b *managedBot) setState(state BotState) {
+	b.mu.Lock()
+	b.state = state
+	b.mu.Unlock()
+}
+
+// BotManager owns the set of currently-running bots and keeps them in sync
+// with bots_config.json, reloading and restarting individual bots as the
+// file changes instead of requiring the whole process to be killed.
+type BotManager struct {
+	configPath string
+	addr       string
+	logFormat  string
+
+	// selection, if non-zero, restricts which configured bots Run/reload
+	// start — the Selection counterpart of Run's --only-run/--skip/--tag
+	// flags. The zero value matches every bot.
+	selection Selection
+
+	// baseCtx is the long-lived context passed into Run, set once Run
+	// starts. The control API's start/reload handlers must start bots
+	// against this rather than the inbound http.Request's context, which
+	// is cancelled the instant the handler returns and would tear the bot
+	// back down milliseconds after starting it.
+	baseCtx context.Context
+
+	mu   sync.Mutex
+	bots map[string]*managedBot
+}
+
+// NewBotManager creates a manager that will watch configPath and serve its
+// control API on addr (e.g. "127.0.0.1:8787"). logFormat ("text" or "json")
+// controls the structured log output of every managed bot.
+func NewBotManager(configPath, addr, logFormat string) *BotManager {
+	return &BotManager{
+		configPath: configPath,
+		addr:       addr,
+		logFormat:  logFormat,
+		bots:       make(map[string]*managedBot),
+	}
+}
+
+// Run loads the initial configuration, starts every configured bot that
+// matches m.selection, then
+// blocks watching configPath for changes and serving the control API until
+// ctx is cancelled.
+func (m *BotManager) Run(ctx context.Context) error {
+	m.baseCtx = ctx
+
+	configs, err := loadConfigs(m.configPath)
+	if err != nil {
+		return err
+	}
+	configs = filterConfigs(configs, m.selection)
+
+	for _, cfg := range configs {
+		m.startBot(ctx, cfg)
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to create config watcher: %w", err)
+	}
+	defer watcher.Close()
+
+	if err := watcher.Add(m.configPath); err != nil {
+		return fmt.Errorf("failed to watch %s: %w", m.configPath, err)
+	}
+
+	srv := m.newControlServer()
+	go func() {
+		log.Printf("Bot control API listening on http://%s\n", m.addr)
+		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Printf("❌ Control API error: %v\n", err)
+		}
+	}()
+	defer srv.Close()
+
+	for {
+		select {
+		case <-ctx.Done():
+			m.stopAll()
+			return nil
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if event.Op&(fsnotify.Write|fsnotify.Create) != 0 {
+				m.reload(ctx)
+			}
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			log.Printf("❌ Config watcher error: %v\n", err)
+		}
+	}
+}
+
+// reload re-reads bots_config.json and diffs it against the running set by
+// BotConfig.ID, starting new entries, stopping removed ones, and restarting
+// any whose configuration changed.
+func (m *BotManager) reload(ctx context.Context) {
+	configs, err := loadConfigs(m.configPath)
+	if err != nil {
+		log.Printf("❌ Failed to reload %s: %v\n", m.configPath, err)
+		return
+	}
+	configs = filterConfigs(configs, m.selection)
+
+	seen := make(map[string]bool, len(configs))
+	for _, cfg := range configs {
+		seen[cfg.ID] = true
+
+		m.mu.Lock()
+		existing, ok := m.bots[cfg.ID]
+		m.mu.Unlock()
+
+		if !ok {
+			log.Printf("➕ Bot '%s' added to config, starting\n", cfg.Name)
+			m.startBot(ctx, cfg)
+			continue
+		}
+
+		current, _ := existing.snapshot()
+		if !reflect.DeepEqual(current, cfg) {
+			log.Printf("🔄 Bot '%s' config changed, restarting\n", cfg.Name)
+			m.startBot(ctx, cfg)
+		}
+	}
+
+	m.mu.Lock()
+	for id, bot := range m.bots {
+		if !seen[id] {
+			log.Printf("➖ Bot '%s' removed from config, stopping\n", bot.config.Name)
+			m.stopBotLocked(id)
+		}
+	}
+	m.mu.Unlock()
+}
+
+func loadConfigs(path string) ([]BotConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var configs []BotConfig
+	if err := json.Unmarshal(data, &configs); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return configs, nil
+}
+
+// startBot launches (or relaunches) the goroutine for cfg, cancelling any
+// previous instance with the same ID first.
+func (m *BotManager) startBot(ctx context.Context, cfg BotConfig) {
+	m.mu.Lock()
+	if old, ok := m.bots[cfg.ID]; ok {
+		old.cancel()
+	}
+
+	botCtx, cancel := context.WithCancel(ctx)
+	bot := &managedBot{config: cfg, cancel: cancel, state: StatePending}
+	m.bots[cfg.ID] = bot
+	m.mu.Unlock()
+
+	go m.runManagedBot(botCtx, bot)
+}
+
+func (m *BotManager) runManagedBot(ctx context.Context, bot *managedBot) {
+	cfg, _ := bot.snapshot()
+
+	logger, closeLog := newBotLogger(m.logFormat, cfg)
+	defer closeLog()
+
+	if cfg.SID == "" || cfg.EventID == "" {
+		logger.Error("missing SID or event ID")
+		bot.setState(StateFailed)
+		return
+	}
+
+	if cfg.Trigger != nil {
+		bot.setState(StatePending)
+		updated, err := waitForTrigger(ctx, cfg, logger)
+		if err != nil {
+			logger.Error("trigger wait failed", "error", err)
+			bot.setState(StateFailed)
+			return
+		}
+		bot.setState(StateRunning)
+		runScraperSupervised(ctx, updated, logger)
+		bot.setState(StateStopped)
+		return
+	}
+
+	runOnce := func(ctx context.Context) {
+		bot.setState(StateRunning)
+		runScraperSupervised(ctx, cfg, logger)
+		bot.setState(StatePending)
+	}
+
+	if cfg.Schedule {
+		bot.setState(StatePending)
+		if err := RunSchedule(ctx, cfg, logger, runOnce); err != nil {
+			logger.Error("schedule error", "error", err)
+			bot.setState(StateFailed)
+		} else {
+			bot.setState(StateStopped)
+		}
+		return
+	}
+
+	select {
+	case <-ctx.Done():
+		bot.setState(StateStopped)
+		return
+	default:
+	}
+
+	runOnce(ctx)
+	bot.setState(StateStopped)
+}
+
+func (m *BotManager) stopBotLocked(id string) {
+	if bot, ok := m.bots[id]; ok {
+		bot.cancel()
+		delete(m.bots, id)
+	}
+}
+
+func (m *BotManager) stopAll() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for id := range m.bots {
+		m.stopBotLocked(id)
+	}
+}
+
+// botStatus is the JSON shape returned by GET /bots.
+type botStatus struct {
+	ID    string   `json:"id"`
+	Name  string   `json:"name"`
+	State BotState `json:"state"`
+}
+
+func (m *BotManager) newControlServer() *http.Server {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/bots", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		m.mu.Lock()
+		statuses := make([]botStatus, 0, len(m.bots))
+		for _, bot := range m.bots {
+			cfg, state := bot.snapshot()
+			statuses = append(statuses, botStatus{ID: cfg.ID, Name: cfg.Name, State: state})
+		}
+		m.mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(statuses)
+	})
+
+	mux.HandleFunc("/bots/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		path := strings.TrimPrefix(r.URL.Path, "/bots/")
+		parts := strings.SplitN(path, "/", 2)
+		if len(parts) != 2 {
+			http.Error(w, "expected /bots/:id/start|stop|reload", http.StatusBadRequest)
+			return
+		}
+		id, action := parts[0], parts[1]
+
+		m.mu.Lock()
+		bot, ok := m.bots[id]
+		m.mu.Unlock()
+
+		switch action {
+		case "stop":
+			if !ok {
+				http.Error(w, "unknown bot id", http.StatusNotFound)
+				return
+			}
+			m.mu.Lock()
+			m.stopBotLocked(id)
+			m.mu.Unlock()
+		case "start", "reload":
+			if !ok {
+				http.Error(w, "unknown bot id", http.StatusNotFound)
+				return
+			}
+			cfg, _ := bot.snapshot()
+			m.startBot(m.baseCtx, cfg)
+		default:
+			http.Error(w, "unknown action: "+action, http.StatusBadRequest)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	return &http.Server{Addr: m.addr, Handler: mux}
+}
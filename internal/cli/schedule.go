@@ -0,0 +1,264 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cronField is the set of values a single cron field (minute, hour, day of
+// month, month, or day of week) is allowed to match. A nil map means "every
+// value" (the field was "*").
+type cronField map[int]bool
+
+func (f cronField) matches(v int) bool {
+	if f == nil {
+		return true
+	}
+	return f[v]
+}
+
+// cronSchedule is a parsed standard 5-field cron expression:
+// minute hour day-of-month month day-of-week
+type cronSchedule struct {
+	minute cronField
+	hour   cronField
+	dom    cronField
+	month  cronField
+	dow    cronField
+}
+
+func parseCron(expr string) (*cronSchedule, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("cron expression must have 5 fields, got %d: %q", len(fields), expr)
+	}
+
+	minute, err := parseCronField(fields[0], 0, 59)
+	if err != nil {
+		return nil, fmt.Errorf("minute field: %w", err)
+	}
+	hour, err := parseCronField(fields[1], 0, 23)
+	if err != nil {
+		return nil, fmt.Errorf("hour field: %w", err)
+	}
+	dom, err := parseCronField(fields[2], 1, 31)
+	if err != nil {
+		return nil, fmt.Errorf("day-of-month field: %w", err)
+	}
+	month, err := parseCronField(fields[3], 1, 12)
+	if err != nil {
+		return nil, fmt.Errorf("month field: %w", err)
+	}
+	dow, err := parseCronField(fields[4], 0, 6)
+	if err != nil {
+		return nil, fmt.Errorf("day-of-week field: %w", err)
+	}
+
+	return &cronSchedule{minute: minute, hour: hour, dom: dom, month: month, dow: dow}, nil
+}
+
+// parseCronField parses a single cron field: "*", "*/n", "a", "a-b",
+// "a-b/n", or a comma-separated list of any of the above.
+func parseCronField(field string, min, max int) (cronField, error) {
+	if field == "*" {
+		return nil, nil
+	}
+
+	set := make(cronField)
+	for _, part := range strings.Split(field, ",") {
+		step := 1
+		rangePart := part
+		if idx := strings.Index(part, "/"); idx != -1 {
+			var err error
+			step, err = strconv.Atoi(part[idx+1:])
+			if err != nil || step <= 0 {
+				return nil, fmt.Errorf("invalid step in %q", part)
+			}
+			rangePart = part[:idx]
+		}
+
+		lo, hi := min, max
+		if rangePart != "*" {
+			if idx := strings.Index(rangePart, "-"); idx != -1 {
+				var err error
+				lo, err = strconv.Atoi(rangePart[:idx])
+				if err != nil {
+					return nil, fmt.Errorf("invalid range start in %q", part)
+				}
+				hi, err = strconv.Atoi(rangePart[idx+1:])
+				if err != nil {
+					return nil, fmt.Errorf("invalid range end in %q", part)
+				}
+			} else {
+				v, err := strconv.Atoi(rangePart)
+				if err != nil {
+					return nil, fmt.Errorf("invalid value %q", part)
+				}
+				lo, hi = v, v
+			}
+		}
+
+		if lo < min || hi > max || lo > hi {
+			return nil, fmt.Errorf("value out of range [%d,%d] in %q", min, max, part)
+		}
+
+		for v := lo; v <= hi; v += step {
+			set[v] = true
+		}
+	}
+
+	return set, nil
+}
+
+// next returns the first point in time strictly after `after` that matches
+// the schedule, searching minute-by-minute up to two years out.
+func (s *cronSchedule) next(after time.Time) (time.Time, error) {
+	t := after.Truncate(time.Minute).Add(time.Minute)
+	limit := after.AddDate(2, 0, 0)
+
+	for t.Before(limit) {
+		if s.month.matches(int(t.Month())) &&
+			s.dayMatches(t) &&
+			s.hour.matches(t.Hour()) &&
+			s.minute.matches(t.Minute()) {
+			return t, nil
+		}
+		t = t.Add(time.Minute)
+	}
+
+	return time.Time{}, fmt.Errorf("no matching cron occurrence within 2 years")
+}
+
+// dayMatches applies the Vixie/POSIX cron quirk for the day-of-month and
+// day-of-week fields: when BOTH are restricted (neither is "*"), a day
+// matching EITHER is enough to fire — e.g. "0 0 1 * 1" means the 1st OR
+// any Monday, not the 1st AND a Monday. Mirrors
+// scheduler.cronSchedule.matches' dom/dow handling.
+func (s *cronSchedule) dayMatches(t time.Time) bool {
+	domRestricted := s.dom != nil
+	dowRestricted := s.dow != nil
+
+	switch {
+	case domRestricted && dowRestricted:
+		return s.dom.matches(t.Day()) || s.dow.matches(int(t.Weekday()))
+	case domRestricted:
+		return s.dom.matches(t.Day())
+	case dowRestricted:
+		return s.dow.matches(int(t.Weekday()))
+	default:
+		return true
+	}
+}
+
+// resolveLocation returns the configured timezone, falling back to local
+// time when Timezone is unset.
+func resolveLocation(cfg BotConfig) (*time.Location, error) {
+	if cfg.Timezone == "" {
+		return time.Local, nil
+	}
+	loc, err := time.LoadLocation(cfg.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timezone %q: %w", cfg.Timezone, err)
+	}
+	return loc, nil
+}
+
+// resolveOneShotTimes parses StartTimes (and, for backward compatibility,
+// the legacy StartDate+StartTime pair) in loc, dropping any that have
+// already passed and returning the rest in chronological order.
+func resolveOneShotTimes(cfg BotConfig, loc *time.Location, now time.Time) ([]time.Time, error) {
+	raw := append([]string{}, cfg.StartTimes...)
+	if len(raw) == 0 && cfg.StartDate != "" && cfg.StartTime != "" {
+		raw = append(raw, fmt.Sprintf("%s %s", cfg.StartDate, cfg.StartTime))
+	}
+
+	var times []time.Time
+	for _, s := range raw {
+		t, err := time.ParseInLocation("2006-01-02 15:04", s, loc)
+		if err != nil {
+			return nil, fmt.Errorf("invalid start time %q: %w", s, err)
+		}
+		if t.Before(now) {
+			continue
+		}
+		times = append(times, t)
+	}
+
+	sort.Slice(times, func(i, j int) bool { return times[i].Before(times[j]) })
+	return times, nil
+}
+
+// sleepUntil blocks until t, the context is cancelled, or the context
+// finishes first, whichever comes first.
+func sleepUntil(ctx context.Context, t time.Time) error {
+	timer := time.NewTimer(time.Until(t))
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// RunSchedule waits for cfg's configured schedule and invokes run at each
+// occurrence. With a Cron expression it keeps computing and waiting for the
+// next occurrence until ctx is cancelled; otherwise it fires once per
+// remaining (non-past) entry in StartTimes/StartDate+StartTime and returns.
+func RunSchedule(ctx context.Context, cfg BotConfig, logger *slog.Logger, run func(context.Context)) error {
+	loc, err := resolveLocation(cfg)
+	if err != nil {
+		return err
+	}
+
+	if cfg.Cron != "" {
+		schedule, err := parseCron(cfg.Cron)
+		if err != nil {
+			return fmt.Errorf("invalid cron expression %q: %w", cfg.Cron, err)
+		}
+
+		for {
+			next, err := schedule.next(time.Now().In(loc))
+			if err != nil {
+				return err
+			}
+			logger.Info("next cron run", "next_run", next.Format("2006-01-02 15:04:05 MST"))
+
+			if err := sleepUntil(ctx, next); err != nil {
+				return fmt.Errorf("schedule cancelled")
+			}
+			run(ctx)
+
+			select {
+			case <-ctx.Done():
+				return fmt.Errorf("schedule cancelled")
+			default:
+			}
+		}
+	}
+
+	times, err := resolveOneShotTimes(cfg, loc, time.Now().In(loc))
+	if err != nil {
+		return err
+	}
+	if len(times) == 0 {
+		return fmt.Errorf("no upcoming start times configured")
+	}
+
+	for _, t := range times {
+		logger.Info("scheduled", "start_time", t.Format("2006-01-02 15:04:05 MST"), "wait", time.Until(t).String())
+		if err := sleepUntil(ctx, t); err != nil {
+			return fmt.Errorf("schedule cancelled")
+		}
+		logger.Info("scheduled time reached, starting")
+		run(ctx)
+	}
+
+	return nil
+}
@@ -0,0 +1,197 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/redis/go-redis/v9"
+)
+
+// TriggerConfig describes an external pub/sub signal a bot can wait on
+// instead of (or alongside) a wall-clock Schedule, so a "sale is live"
+// notifier can fire the scraper without restarting bots.
+type TriggerConfig struct {
+	Type    string `json:"type"`             // "nats", "redis", or "http"
+	URL     string `json:"url"`              // broker URL, or listen address for "http"
+	Subject string `json:"subject"`          // NATS subject, Redis channel, or HTTP path
+	Filter  string `json:"filter,omitempty"` // optional substring the raw payload must contain to count as a match
+}
+
+// triggerOverride is the optional JSON payload a trigger message carries to
+// override per-run fields without restarting the bot.
+type triggerOverride struct {
+	TicketID    string `json:"ticket_id,omitempty"`
+	Filter      string `json:"filter,omitempty"`
+	Quantity    string `json:"quantity,omitempty"`
+	PreSaleCode string `json:"pre_sale_code,omitempty"`
+}
+
+func (o triggerOverride) applyTo(cfg BotConfig) BotConfig {
+	if o.TicketID != "" {
+		cfg.TicketID = o.TicketID
+	}
+	if o.Filter != "" {
+		cfg.Filter = o.Filter
+	}
+	if o.Quantity != "" {
+		cfg.Quantity = o.Quantity
+	}
+	if o.PreSaleCode != "" {
+		cfg.PreSaleCode = o.PreSaleCode
+	}
+	return cfg
+}
+
+// waitForTrigger blocks until cfg's Trigger delivers a matching message,
+// cfg's Schedule (if also set) reaches its next one-shot time, or ctx is
+// cancelled — whichever happens first — returning cfg with any override
+// fields from the trigger payload applied.
+func waitForTrigger(ctx context.Context, cfg BotConfig, logger *slog.Logger) (BotConfig, error) {
+	if cfg.Trigger == nil {
+		return cfg, fmt.Errorf("no trigger configured")
+	}
+
+	payloadCh := make(chan []byte, 8)
+	errCh := make(chan error, 1)
+
+	triggerCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go subscribeTrigger(triggerCtx, *cfg.Trigger, payloadCh, errCh)
+
+	var scheduleCh <-chan time.Time
+	if cfg.Schedule {
+		if loc, err := resolveLocation(cfg); err == nil {
+			if times, err := resolveOneShotTimes(cfg, loc, time.Now().In(loc)); err == nil && len(times) > 0 {
+				timer := time.NewTimer(time.Until(times[0]))
+				defer timer.Stop()
+				scheduleCh = timer.C
+			}
+		}
+	}
+
+	for {
+		select {
+		case payload := <-payloadCh:
+			if cfg.Trigger.Filter != "" && !strings.Contains(string(payload), cfg.Trigger.Filter) {
+				continue
+			}
+
+			var override triggerOverride
+			if len(payload) > 0 {
+				if err := json.Unmarshal(payload, &override); err != nil {
+					logger.Warn("trigger payload was not valid JSON, ignoring overrides", "error", err)
+				}
+			}
+			logger.Info("trigger fired", "type", cfg.Trigger.Type, "subject", cfg.Trigger.Subject)
+			return override.applyTo(cfg), nil
+
+		case <-scheduleCh:
+			logger.Info("scheduled time reached before trigger fired")
+			return cfg, nil
+
+		case err := <-errCh:
+			return cfg, err
+
+		case <-ctx.Done():
+			return cfg, ctx.Err()
+		}
+	}
+}
+
+// subscribeTrigger connects to trig's broker and forwards every raw message
+// payload it receives onto payloadCh until ctx is cancelled, or reports a
+// connection error on errCh.
+func subscribeTrigger(ctx context.Context, trig TriggerConfig, payloadCh chan<- []byte, errCh chan<- error) {
+	switch trig.Type {
+	case "nats":
+		subscribeNATS(ctx, trig, payloadCh, errCh)
+	case "redis":
+		subscribeRedis(ctx, trig, payloadCh, errCh)
+	case "http":
+		subscribeHTTP(ctx, trig, payloadCh, errCh)
+	default:
+		errCh <- fmt.Errorf("unknown trigger type %q (expected nats, redis, or http)", trig.Type)
+	}
+}
+
+func subscribeNATS(ctx context.Context, trig TriggerConfig, payloadCh chan<- []byte, errCh chan<- error) {
+	nc, err := nats.Connect(trig.URL)
+	if err != nil {
+		errCh <- fmt.Errorf("nats connect: %w", err)
+		return
+	}
+	defer nc.Close()
+
+	sub, err := nc.SubscribeSync(trig.Subject)
+	if err != nil {
+		errCh <- fmt.Errorf("nats subscribe: %w", err)
+		return
+	}
+	defer sub.Unsubscribe()
+
+	for {
+		msg, err := sub.NextMsgWithContext(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			errCh <- fmt.Errorf("nats receive: %w", err)
+			return
+		}
+		payloadCh <- msg.Data
+	}
+}
+
+func subscribeRedis(ctx context.Context, trig TriggerConfig, payloadCh chan<- []byte, errCh chan<- error) {
+	rdb := redis.NewClient(&redis.Options{Addr: trig.URL})
+	defer rdb.Close()
+
+	pubsub := rdb.Subscribe(ctx, trig.Subject)
+	defer pubsub.Close()
+
+	ch := pubsub.Channel()
+	for {
+		select {
+		case msg, ok := <-ch:
+			if !ok {
+				return
+			}
+			payloadCh <- []byte(msg.Payload)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// subscribeHTTP runs a tiny local HTTP server so an external notifier can
+// POST a trigger payload to trig.Subject (a URL path) instead of owning a
+// broker connection.
+func subscribeHTTP(ctx context.Context, trig TriggerConfig, payloadCh chan<- []byte, errCh chan<- error) {
+	mux := http.NewServeMux()
+	mux.HandleFunc(trig.Subject, func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		payloadCh <- body
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	srv := &http.Server{Addr: trig.URL, Handler: mux}
+	go func() {
+		<-ctx.Done()
+		srv.Close()
+	}()
+
+	if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		errCh <- fmt.Errorf("http trigger listener: %w", err)
+	}
+}
@@ -0,0 +1,119 @@
+// Package command is a minimal subcommand-dispatch framework for
+// cmd/tix-scraper and cmd/tix-scraper-cli: named subcommands, each with
+// its own flag.FlagSet, plus a --help listing that can enumerate
+// dynamically discovered tasks (e.g. configured bot names) below the
+// registered commands. Neither urfave/cli nor spf13/cobra is a dependency
+// of this module, so this sticks to the standard library's flag package
+// rather than adding one just for ~half a dozen verbs.
+package command
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+)
+
+// Command is one subcommand: a name users type after the binary (e.g.
+// "run-bot"), a one-line Usage description, an optional Flags callback to
+// register its own flags, and the Run function invoked with the parsed
+// flag set and remaining positional args.
+type Command struct {
+	Name  string
+	Usage string
+	Flags func(fs *flag.FlagSet)
+	Run   func(fs *flag.FlagSet, args []string) error
+}
+
+// Registry holds every registered Command and dispatches os.Args[1:] (or
+// an equivalent slice) to the right one.
+type Registry struct {
+	name     string
+	commands []*Command
+
+	// ExtraTasks, if set, is called when printing usage/help to list
+	// additional discoverable tasks below the registered commands — e.g.
+	// cmd/tix-scraper uses this to print each configured bot's name, the
+	// way gopherbot's usage enumerates its tasks.
+	ExtraTasks func() []string
+}
+
+// NewRegistry returns an empty Registry; name is the binary name shown in
+// usage output.
+func NewRegistry(name string) *Registry {
+	return &Registry{name: name}
+}
+
+// Register adds cmd to the registry. Panics on a duplicate name, since
+// that's a programming error caught at startup, not a runtime condition.
+func (r *Registry) Register(cmd *Command) {
+	if r.lookup(cmd.Name) != nil {
+		panic(fmt.Sprintf("command: %q already registered", cmd.Name))
+	}
+	r.commands = append(r.commands, cmd)
+}
+
+// CommandNames returns the name of every registered command, in
+// registration order — used to generate shell completion scripts without
+// duplicating the command list by hand.
+func (r *Registry) CommandNames() []string {
+	names := make([]string, len(r.commands))
+	for i, c := range r.commands {
+		names[i] = c.Name
+	}
+	return names
+}
+
+func (r *Registry) lookup(name string) *Command {
+	for _, c := range r.commands {
+		if c.Name == name {
+			return c
+		}
+	}
+	return nil
+}
+
+// Dispatch parses args[0] as the subcommand name and runs it with the
+// remaining args. Empty args, "help", "-h", or "--help" print Usage
+// instead of running anything and return nil.
+func (r *Registry) Dispatch(args []string) error {
+	if len(args) == 0 || args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+		fmt.Print(r.Usage())
+		return nil
+	}
+
+	cmd := r.lookup(args[0])
+	if cmd == nil {
+		fmt.Print(r.Usage())
+		return fmt.Errorf("command: unknown subcommand %q", args[0])
+	}
+
+	fs := flag.NewFlagSet(cmd.Name, flag.ContinueOnError)
+	if cmd.Flags != nil {
+		cmd.Flags(fs)
+	}
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+	return cmd.Run(fs, fs.Args())
+}
+
+// Usage renders every registered command plus, if ExtraTasks is set, the
+// tasks it returns — what --help prints.
+func (r *Registry) Usage() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Usage: %s <command> [flags]\n\nCommands:\n", r.name)
+	for _, c := range r.commands {
+		fmt.Fprintf(&b, "  %-14s %s\n", c.Name, c.Usage)
+	}
+
+	if r.ExtraTasks != nil {
+		if tasks := r.ExtraTasks(); len(tasks) > 0 {
+			b.WriteString("\nConfigured bots (pass a name or index to run-bot):\n")
+			for _, t := range tasks {
+				fmt.Fprintf(&b, "  %s\n", t)
+			}
+		}
+	}
+
+	return b.String()
+}
@@ -0,0 +1,172 @@
+// Package daemon owns bot lifecycles independently of any UI, so bots keep
+// executing whether or not a window is open. State changes are published on
+// a channel-based update bus instead of being written directly into UI
+// state, letting a GUI (or a tray icon) render a view onto it.
+package daemon
+
+import (
+	"context"
+	"sync"
+
+	"tix-scraper/internal/services"
+)
+
+// State describes where a managed bot is in its lifecycle.
+type State string
+
+const (
+	StateIdle    State = "idle"
+	StateRunning State = "running"
+	StateFailed  State = "failed"
+)
+
+// BotConfig holds the fields a daemon needs to drive one bot headlessly.
+type BotConfig struct {
+	ID          string
+	Name        string
+	SID         string
+	EventID     string
+	TicketID    string
+	Filter      string
+	Quantity    string
+	MaxTickets  string
+	PreSaleCode string
+	Loop        bool
+}
+
+// Status is an aggregate snapshot of one managed bot, published on the
+// update bus and returned by Snapshot.
+type Status struct {
+	ID    string
+	Name  string
+	State State
+}
+
+type managedBot struct {
+	mu     sync.Mutex
+	config BotConfig
+	state  State
+	cancel context.CancelFunc
+}
+
+func (b *managedBot) setState(state State) {
+	b.mu.Lock()
+	b.state = state
+	b.mu.Unlock()
+}
+
+// Daemon owns the set of currently-running bots.
+type Daemon struct {
+	mu      sync.Mutex
+	bots    map[string]*managedBot
+	updates chan Status
+}
+
+// New returns an empty Daemon with no bots running.
+func New() *Daemon {
+	return &Daemon{
+		bots:    make(map[string]*managedBot),
+		updates: make(chan Status, 16),
+	}
+}
+
+// Updates returns the channel aggregate status changes are published on.
+func (d *Daemon) Updates() <-chan Status {
+	return d.updates
+}
+
+func (d *Daemon) publish(bot *managedBot) {
+	bot.mu.Lock()
+	status := Status{ID: bot.config.ID, Name: bot.config.Name, State: bot.state}
+	bot.mu.Unlock()
+
+	select {
+	case d.updates <- status:
+	default:
+		// Drop the update rather than block a bot goroutine on a slow
+		// subscriber; Snapshot always reflects current state regardless.
+	}
+}
+
+// Start launches (or relaunches) cfg's bot, cancelling any previous instance
+// with the same ID first.
+func (d *Daemon) Start(cfg BotConfig) {
+	d.mu.Lock()
+	if old, ok := d.bots[cfg.ID]; ok {
+		old.cancel()
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	bot := &managedBot{config: cfg, cancel: cancel, state: StateIdle}
+	d.bots[cfg.ID] = bot
+	d.mu.Unlock()
+
+	go d.run(ctx, bot)
+}
+
+func (d *Daemon) run(ctx context.Context, bot *managedBot) {
+	bot.setState(StateRunning)
+	d.publish(bot)
+
+	bot.mu.Lock()
+	cfg := bot.config
+	bot.mu.Unlock()
+
+	scraperCfg := services.ScraperConfig{
+		BaseURL:        "https://tixcraft.com/ticket/area",
+		EventID:        cfg.EventID,
+		TicketID:       cfg.TicketID,
+		Filter:         cfg.Filter,
+		PerOrderTicket: cfg.Quantity,
+		MaxTickets:     cfg.MaxTickets,
+		PreSaleCode:    cfg.PreSaleCode,
+		SessionID:      cfg.SID,
+		Loop:           cfg.Loop,
+	}
+	if err := services.RunScraper(ctx, scraperCfg); err != nil {
+		bot.setState(StateFailed)
+	} else {
+		bot.setState(StateIdle)
+	}
+	d.publish(bot)
+}
+
+// Stop cancels the bot with the given ID, if one is running.
+func (d *Daemon) Stop(id string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if bot, ok := d.bots[id]; ok {
+		bot.cancel()
+	}
+}
+
+// StartAll launches every config in cfgs.
+func (d *Daemon) StartAll(cfgs []BotConfig) {
+	for _, cfg := range cfgs {
+		d.Start(cfg)
+	}
+}
+
+// StopAll cancels every currently managed bot.
+func (d *Daemon) StopAll() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for _, bot := range d.bots {
+		bot.cancel()
+	}
+}
+
+// Snapshot returns the current status of every managed bot, e.g. for
+// rendering an aggregate "idle / N running / error" tray tooltip.
+func (d *Daemon) Snapshot() []Status {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	out := make([]Status, 0, len(d.bots))
+	for _, bot := range d.bots {
+		bot.mu.Lock()
+		out = append(out, Status{ID: bot.config.ID, Name: bot.config.Name, State: bot.state})
+		bot.mu.Unlock()
+	}
+	return out
+}
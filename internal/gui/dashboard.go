@@ -0,0 +1,224 @@
+package gui
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"tix-scraper/internal/logbus"
+	"tix-scraper/internal/metrics"
+)
+
+// defaultLogPageSize and maxLogPageSize bound GET /logs the same way a
+// paginated API in this codebase always should: a sane default, and a
+// hard ceiling so a client can't request the entire ring buffer in one
+// response.
+const (
+	defaultLogPageSize = 200
+	maxLogPageSize     = 2000
+)
+
+// NewDashboardServer returns an *http.Server exposing bot status and log
+// history over HTTP, so a long-running scrape can be watched from a
+// browser or scripted from another process. It reads and writes through
+// UIFrontend, the same interface the terminal UI drives, so nothing about
+// bot state or log storage diverges between the three frontends.
+//
+//	GET  /bots                                    bot list + status, JSON
+//	GET  /logs?bot=X&level=warn&since=<RFC3339>    paginated log entries
+//	GET  /logs/stream                              SSE tail of new entries
+//	GET  /metrics                                  Prometheus text exposition
+//	POST /bots/{id}/start
+//	POST /bots/{id}/stop
+func NewDashboardServer(frontend UIFrontend, addr string) *http.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/bots", dashboardBots(frontend))
+	mux.HandleFunc("/bots/", dashboardBotAction(frontend))
+	mux.HandleFunc("/logs", dashboardLogs(frontend))
+	mux.HandleFunc("/logs/stream", dashboardLogStream(frontend))
+	mux.Handle("/metrics", metrics.DefaultRegistry.Handler())
+	return &http.Server{Addr: addr, Handler: mux}
+}
+
+func dashboardBots(frontend UIFrontend) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(frontend.Bots())
+	}
+}
+
+func dashboardBotAction(frontend UIFrontend) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		path := strings.TrimPrefix(r.URL.Path, "/bots/")
+		parts := strings.SplitN(path, "/", 2)
+		if len(parts) != 2 {
+			http.Error(w, "expected /bots/:id/start|stop", http.StatusBadRequest)
+			return
+		}
+		id, action := parts[0], parts[1]
+
+		var err error
+		switch action {
+		case "start":
+			err = frontend.StartBot(id)
+		case "stop":
+			err = frontend.StopBot(id)
+		default:
+			http.Error(w, "unknown action: "+action, http.StatusBadRequest)
+			return
+		}
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func dashboardLogs(frontend UIFrontend) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		query := r.URL.Query()
+		botName := query.Get("bot")
+		minLevel := logbus.LevelInfo
+		if lv := query.Get("level"); lv != "" {
+			minLevel = parseLevel(lv)
+		}
+
+		var since time.Time
+		if s := query.Get("since"); s != "" {
+			parsed, err := time.Parse(time.RFC3339, s)
+			if err != nil {
+				http.Error(w, "invalid since: "+err.Error(), http.StatusBadRequest)
+				return
+			}
+			since = parsed
+		}
+
+		limit := defaultLogPageSize
+		if l := query.Get("limit"); l != "" {
+			if n, err := strconv.Atoi(l); err == nil && n > 0 {
+				limit = n
+			}
+		}
+		if limit > maxLogPageSize {
+			limit = maxLogPageSize
+		}
+
+		matched := make([]logbus.LogEntry, 0, limit)
+		for _, entry := range frontend.LogSnapshot() {
+			if botName != "" && entry.BotName != botName {
+				continue
+			}
+			if entry.Level < minLevel {
+				continue
+			}
+			if !since.IsZero() && !entry.Timestamp.After(since) {
+				continue
+			}
+			matched = append(matched, entry)
+		}
+
+		nextSince := since
+		truncated := false
+		if len(matched) > limit {
+			matched = matched[:limit]
+			truncated = true
+		}
+		if len(matched) > 0 {
+			nextSince = matched[len(matched)-1].Timestamp
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(struct {
+			Entries   []logbus.LogEntry `json:"entries"`
+			NextSince time.Time         `json:"next_since"`
+			Truncated bool              `json:"truncated"`
+		}{matched, nextSince, truncated})
+	}
+}
+
+// dashboardLogStream serves GET /logs/stream as Server-Sent Events: every
+// entry published on frontend.LogBus() after the connection opens is
+// pushed to the client as one "data: <json>\n\n" frame, until the request
+// context is cancelled (the client disconnects).
+func dashboardLogStream(frontend UIFrontend) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("Connection", "keep-alive")
+
+		sink := newSSESink()
+		frontend.LogBus().AddSink(sink)
+		defer frontend.LogBus().RemoveSink(sink)
+
+		for {
+			select {
+			case <-r.Context().Done():
+				return
+			case entry := <-sink.entries:
+				data, err := json.Marshal(entry)
+				if err != nil {
+					continue
+				}
+				fmt.Fprintf(w, "data: %s\n\n", data)
+				flusher.Flush()
+			}
+		}
+	}
+}
+
+// sseSink is a logbus.LogSink that forwards entries to a channel a single
+// /logs/stream request reads from; entries published after the channel's
+// buffer fills are dropped rather than blocking the whole LogBus.
+type sseSink struct {
+	entries chan logbus.LogEntry
+}
+
+func newSSESink() *sseSink {
+	return &sseSink{entries: make(chan logbus.LogEntry, 64)}
+}
+
+func (s *sseSink) Publish(entry logbus.LogEntry) error {
+	select {
+	case s.entries <- entry:
+	default:
+	}
+	return nil
+}
+
+func parseLevel(s string) logbus.LogLevel {
+	switch strings.ToLower(s) {
+	case "debug":
+		return logbus.LevelDebug
+	case "warn", "warning":
+		return logbus.LevelWarn
+	case "error":
+		return logbus.LevelError
+	default:
+		return logbus.LevelInfo
+	}
+}
@@ -0,0 +1,106 @@
+package gui
+
+import (
+	"fmt"
+
+	"tix-scraper/internal/android"
+	"tix-scraper/internal/logbus"
+)
+
+// UIFrontend is the common surface the Gio GUI and the terminal UI
+// (internal/tui) both expose for driving a bot run: the current bot list,
+// start/stop by ID, and the LogBus carrying every bot's (and the
+// frontend's own) log output. Code that only needs to drive bots — not
+// render them — should depend on this instead of *GUI directly.
+type UIFrontend interface {
+	// Bots returns the current configuration of every bot, in display
+	// order.
+	Bots() []BotConfig
+
+	// StartBot starts the bot with the given ID, as if its Start button
+	// had been clicked. It returns an error if no such bot exists or it
+	// is already running.
+	StartBot(id string) error
+
+	// StopBot cancels the bot with the given ID, as if its Stop button
+	// had been clicked. It returns an error if no such bot exists or it
+	// is not currently running.
+	StopBot(id string) error
+
+	// LogBus returns the bus every bot's output, and the frontend's own
+	// messages, are published on.
+	LogBus() *logbus.LogBus
+
+	// LogSnapshot returns every log entry currently held in memory,
+	// oldest first, for callers (like the HTTP dashboard) that need the
+	// backlog rather than just new entries off LogBus.
+	LogSnapshot() []logbus.LogEntry
+}
+
+var _ UIFrontend = (*GUI)(nil)
+
+// Bots implements UIFrontend.
+func (g *GUI) Bots() []BotConfig {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	configs := make([]BotConfig, len(g.bots))
+	for i, bot := range g.bots {
+		configs[i] = bot.config
+	}
+	return configs
+}
+
+// StartBot implements UIFrontend.
+func (g *GUI) StartBot(id string) error {
+	bot := g.botByID(id)
+	if bot == nil {
+		return fmt.Errorf("no such bot: %s", id)
+	}
+	if bot.config.IsRunning {
+		return fmt.Errorf("bot %s is already running", id)
+	}
+	g.startBot(bot)
+	return nil
+}
+
+// StopBot implements UIFrontend.
+func (g *GUI) StopBot(id string) error {
+	bot := g.botByID(id)
+	if bot == nil {
+		return fmt.Errorf("no such bot: %s", id)
+	}
+	if !bot.config.IsRunning {
+		return fmt.Errorf("bot %s is not running", id)
+	}
+
+	bot.cancel()
+	if android.Supported() {
+		android.Default.CancelWake(bot.config.ID)
+	}
+	bot.config.IsRunning = false
+	g.invalidate()
+	return nil
+}
+
+// LogBus implements UIFrontend.
+func (g *GUI) LogBus() *logbus.LogBus {
+	return g.logBus
+}
+
+// LogSnapshot implements UIFrontend.
+func (g *GUI) LogSnapshot() []logbus.LogEntry {
+	return g.logView.Snapshot()
+}
+
+func (g *GUI) botByID(id string) *Bot {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	for _, bot := range g.bots {
+		if bot.config.ID == id {
+			return bot
+		}
+	}
+	return nil
+}
@@ -9,13 +9,23 @@ import (
 	"io"
 	"log"
 	"os"
+	"path/filepath"
 	"strings"
 	"sync"
 	"time"
 
+	"tix-scraper/internal/android"
+	"tix-scraper/internal/auth/totp"
+	"tix-scraper/internal/daemon"
+	"tix-scraper/internal/index"
+	"tix-scraper/internal/logbus"
+	"tix-scraper/internal/paths"
+	"tix-scraper/internal/scheduler"
 	"tix-scraper/internal/services"
+	"tix-scraper/internal/vault"
 
 	"gioui.org/app"
+	"gioui.org/font"
 	"gioui.org/layout"
 	"gioui.org/op"
 	"gioui.org/op/clip"
@@ -46,6 +56,10 @@ var (
 	purpleAccent  = color.NRGBA{R: 180, G: 142, B: 173, A: 255}
 )
 
+// vaultLockTimeout auto-locks the vault (zeroizing the derived key) after
+// this much inactivity, re-prompting via the passphrase modal.
+const vaultLockTimeout = 15 * time.Minute
+
 type BotConfig struct {
 	ID          string `json:"id"`
 	Name        string `json:"name"`
@@ -59,8 +73,9 @@ type BotConfig struct {
 	PreSaleCode string `json:"pre_sale_code"`
 	Loop        bool   `json:"loop"`
 	Schedule    bool   `json:"schedule"`
-	StartDate   string `json:"start_date"` // Format: "2006-01-02"
-	StartTime   string `json:"start_time"` // Format: "15:04"
+	StartDate   string `json:"start_date"`          // Format: "2006-01-02"
+	StartTime   string `json:"start_time"`          // Format: "15:04"
+	TOTPSite    string `json:"totp_site,omitempty"` // key into the TOTP tab's enrolled secrets, if this vendor gates behind 2FA
 	IsRunning   bool   `json:"-"`
 }
 
@@ -75,15 +90,78 @@ type GUI struct {
 	bots           []*Bot
 	selectedBot    int
 	addBotBtn      widget.Clickable
+	importBotBtn   widget.Clickable
 	saveAllBtn     widget.Clickable // Added save all button
 	logView        *LogView
+	logBus         *logbus.LogBus
 	usersView      *UsersView
 	showUsers      bool
 	botsTabBtn     widget.Clickable
 	usersTabBtn    widget.Clickable
+	otpTabBtn      widget.Clickable
+	otpView        *OTPView
+	showOTP        bool
+	jobsTabBtn     widget.Clickable
+	jobsView       *JobsView
+	showJobs       bool
+	watchesTabBtn  widget.Clickable
+	watchesView    *WatchesView
+	showWatches    bool
 	mu             sync.Mutex
 	isMobile       bool // Track if we're on a small screen
 	mainScrollList widget.List
+
+	trayMode bool
+	daemon   *daemon.Daemon // owns bot lifecycles independently of the window in tray mode
+
+	configDir string // resolved by paths.ConfigDir; holds bots_config.json and the vault
+
+	vault         *vault.Vault
+	vaultUnlocked bool
+	passModal     passphraseModal
+
+	totp *totp.Provisioner
+
+	scheduler *scheduler.Scheduler
+
+	listingIndex *index.Index
+	watches      *index.Manager
+
+	qr qrModal
+
+	selfWriteUntil time.Time // suppresses watchDataDir reloads shortly after our own saves
+}
+
+// botsConfigPath, vaultPath, and legacyUsersPath are g.configDir joined
+// with the well-known filenames paths.ConfigDir migrates and watchDataDir
+// watches.
+func (g *GUI) botsConfigPath() string   { return filepath.Join(g.configDir, paths.BotsConfigFile) }
+func (g *GUI) vaultPath() string        { return filepath.Join(g.configDir, paths.VaultFile) }
+func (g *GUI) legacyUsersPath() string  { return filepath.Join(g.configDir, paths.LegacyUsersFile) }
+func (g *GUI) totpPath() string         { return filepath.Join(g.configDir, paths.TOTPVaultFile) }
+func (g *GUI) schedulerDBPath() string  { return filepath.Join(g.configDir, paths.SchedulerDBFile) }
+func (g *GUI) listingIndexPath() string { return filepath.Join(g.configDir, paths.ListingIndexDir) }
+func (g *GUI) watchesPath() string      { return filepath.Join(g.configDir, paths.WatchesFile) }
+
+// Notify implements index.Notifier as a desktop notification channel: this
+// repo doesn't carry a platform-specific OS-toast library, so "desktop
+// notification" means writing a line to the same log panel every other
+// GUI event goes through.
+func (g *GUI) Notify(w *index.Watch, l index.Listing) error {
+	g.logView.Write([]byte(fmt.Sprintf("👀 Watch %q matched: %s %s row %s seat %s at $%.2f\n",
+		w.Name, l.Title, l.Section, l.Row, l.Seat, l.Price)))
+	return nil
+}
+
+// invalidate requests a redraw if a window is actually being shown. g.w is
+// nil when a *GUI is driven headlessly (see internal/tui), so the bot,
+// tab and job call sites go through here instead of calling
+// g.w.Invalidate() directly. UsersView, the QR modal and the passphrase
+// modal still call g.w.Invalidate() themselves and need a window.
+func (g *GUI) invalidate() {
+	if g.w != nil {
+		g.w.Invalidate()
+	}
 }
 
 type Bot struct {
@@ -100,6 +178,7 @@ type Bot struct {
 	quantityEditor   TextField
 	maxTicketsEditor TextField
 	preSaleEditor    TextField
+	totpSiteEditor   TextField
 
 	// Schedule widgets
 	scheduleCheckbox widget.Bool
@@ -109,7 +188,11 @@ type Bot struct {
 
 	loopCheckbox widget.Bool
 	runBtn       widget.Clickable
+	shareBtn     widget.Clickable
 	cancel       context.CancelFunc
+
+	metrics      *BotMetrics // non-nil only while the bot is running
+	metricsPanel *metricsPanel
 }
 
 // New TextField type to handle value extraction
@@ -142,6 +225,9 @@ type UsersView struct {
 	deleteButtons []widget.Clickable
 	deleteAllBtn  widget.Clickable
 	refreshBtn    widget.Clickable
+	lockBtn       widget.Clickable
+	importBtn     widget.Clickable
+	shareButtons  []widget.Clickable
 	mu            sync.Mutex
 	sidEditor     TextField
 	validateBtn   widget.Clickable
@@ -217,7 +303,18 @@ func (d *Dropdown) Layout(gtx C, th *material.Theme) D {
 	)
 }
 
-func NewGUI() *GUI {
+// NewGUI builds the GUI and resolves its config directory: configDirOverride
+// (the -config-dir flag) wins if non-empty, then $TIX_CONFIG_DIR, then the
+// OS's per-user config directory, falling back to ./data if none of those
+// can be resolved or created.
+func NewGUI(configDirOverride string) *GUI {
+	dir, err := paths.ConfigDir(configDirOverride)
+	if err != nil {
+		log.Printf("❌ Error resolving config directory, falling back to ./data: %v\n", err)
+		dir = "data"
+		os.MkdirAll(dir, 0700)
+	}
+
 	th := material.NewTheme()
 	th.Palette.Bg = bgColor
 	th.Palette.Fg = textColor
@@ -225,17 +322,51 @@ func NewGUI() *GUI {
 	g := &GUI{
 		th:          th,
 		selectedBot: -1,
-		logView:     &LogView{},
+		logView:     &LogView{ring: logbus.NewLogRingBuffer(logbus.DefaultRingCapacity)},
 		usersView:   &UsersView{},
 		showUsers:   false,
+		otpView:     &OTPView{},
+		jobsView:    &JobsView{},
+		watchesView: &WatchesView{},
 		mainScrollList: widget.List{
 			List: layout.List{Axis: layout.Vertical},
 		},
+		configDir: dir,
+		passModal: newPassphraseModal(),
 	}
+	g.vault = vault.New(g.vaultPath(), vaultLockTimeout)
+	g.totp = totp.NewProvisioner(g.totpPath(), vaultLockTimeout)
+	g.logView.gui = g
+	g.logBus = newGUILogBus(dir, g.logView)
 
 	g.usersView.gui = g
+	g.otpView.gui = g
+	g.jobsView.gui = g
+	g.watchesView.gui = g
+
+	if store, err := scheduler.OpenBuntStore(g.schedulerDBPath()); err != nil {
+		log.Printf("⚠️ Scheduler disabled: could not open job store: %v\n", err)
+	} else {
+		g.scheduler = scheduler.NewScheduler(store, g.runScheduledJob, scheduler.RunOnce)
+		go g.scheduler.Start(context.Background())
+	}
+
+	if idx, err := index.Open(g.listingIndexPath()); err != nil {
+		log.Printf("⚠️ Listing search disabled: could not open index: %v\n", err)
+	} else {
+		g.listingIndex = idx
+		if watches, err := index.NewManager(idx, g.watchesPath()); err != nil {
+			log.Printf("⚠️ Watches disabled: %v\n", err)
+		} else {
+			g.watches = watches
+			g.watches.AddNotifier(g)
+			g.watches.AddNotifier(index.WebhookNotifier{})
+		}
+	}
+
 	g.loadBots()
-	g.usersView.loadUsers()
+	// Users are only loaded once the master passphrase modal unlocks the
+	// vault; see layoutPassphraseModal / unlockVault.
 
 	if len(g.bots) == 0 {
 		g.addBot()
@@ -245,12 +376,34 @@ func NewGUI() *GUI {
 	return g
 }
 
+// newGUILogBus wires up the structured logging pipeline: the GUI's own log
+// panel, a rotating text file per bot, and a JSON-lines file for post-
+// mortem analysis, all under configDir/logs. A sink that fails to open
+// (e.g. a read-only configDir) is skipped with a message to the list sink
+// rather than aborting startup.
+func newGUILogBus(configDir string, listSink logbus.LogSink) *logbus.LogBus {
+	bus := logbus.NewBus()
+	bus.AddSink(listSink)
+
+	logsDir := filepath.Join(configDir, "logs")
+	bus.AddSink(logbus.NewRotatingFileSink(logsDir))
+
+	if sink, err := logbus.NewJSONLSink(filepath.Join(logsDir, "events.jsonl")); err == nil {
+		bus.AddSink(sink)
+	} else {
+		bus.Errorf("", "Could not open JSON-lines log file: %v", err)
+	}
+
+	return bus
+}
+
 func (g *GUI) addBot() {
 	bot := &Bot{
 		config: BotConfig{
 			ID:   fmt.Sprintf("bot_%d", time.Now().Unix()),
 			Name: fmt.Sprintf("Bot #%d", len(g.bots)+1),
 		},
+		metricsPanel: newMetricsPanel(),
 	}
 
 	// Initialize text fields with proper values
@@ -261,6 +414,7 @@ func (g *GUI) addBot() {
 	bot.quantityEditor = TextField{Editor: widget.Editor{SingleLine: true}}
 	bot.maxTicketsEditor = TextField{Editor: widget.Editor{SingleLine: true}}
 	bot.preSaleEditor = TextField{Editor: widget.Editor{SingleLine: true}}
+	bot.totpSiteEditor = TextField{Editor: widget.Editor{SingleLine: true}}
 	bot.dateEditor = TextField{Editor: widget.Editor{SingleLine: true}}
 	bot.hourEditor = TextField{Editor: widget.Editor{SingleLine: true}}
 	bot.minuteEditor = TextField{Editor: widget.Editor{SingleLine: true}}
@@ -274,6 +428,34 @@ func (g *GUI) addBot() {
 }
 
 func (g *GUI) Run() {
+	g.logView.gui = g
+	g.watchDataDir()
+	g.openWindow()
+	app.Main()
+}
+
+// RunTray starts the daemon to drive every configured bot headlessly and
+// shows a system tray icon instead of exiting when the window is closed, so
+// long scheduled runs survive an accidental (or deliberate) close. The tray
+// menu exposes Start All / Stop All / Show Window / Quit.
+func (g *GUI) RunTray() {
+	g.trayMode = true
+	g.logView.gui = g
+
+	g.daemon = daemon.New()
+	g.daemon.StartAll(g.daemonConfigs())
+
+	go g.runTrayIcon()
+	g.watchDataDir()
+
+	g.openWindow()
+	app.Main()
+}
+
+// openWindow creates and shows the main app.Window, running its event loop
+// in its own goroutine. In tray mode it can be called again after the
+// window has been closed to reopen it.
+func (g *GUI) openWindow() {
 	g.w = new(app.Window)
 
 	// Set responsive window size
@@ -282,15 +464,15 @@ func (g *GUI) Run() {
 		app.Size(unit.Dp(1000), unit.Dp(700)),   // Smaller default size
 		app.MinSize(unit.Dp(400), unit.Dp(500)), // Minimum size for mobile
 	)
-	g.logView.gui = g
 
 	go func() {
-		if err := g.loop(); err != nil {
+		if err := g.loop(); err != nil && !g.trayMode {
 			log.Fatal(err)
 		}
-		os.Exit(0)
+		if !g.trayMode {
+			os.Exit(0)
+		}
 	}()
-	app.Main()
 }
 
 func (g *GUI) loop() error {
@@ -299,6 +481,12 @@ func (g *GUI) loop() error {
 		switch e := g.w.Event().(type) {
 		case app.DestroyEvent:
 			g.saveBots()
+			if g.trayMode {
+				// Keep the daemon (and its bots) running; the tray's
+				// "Show Window" action will call openWindow again.
+				g.w = nil
+				return nil
+			}
 			return e.Err
 		case app.FrameEvent:
 			gtx := app.NewContext(&ops, e)
@@ -313,11 +501,17 @@ func (g *GUI) loop() error {
 }
 
 func (g *GUI) Layout(gtx C) D {
+	if !g.vaultUnlocked {
+		return g.layoutPassphraseModal(gtx)
+	}
+
 	paint.Fill(gtx.Ops, bgColor)
 
+	var dims D
+
 	// Responsive layout - stack vertically on mobile
 	if g.isMobile {
-		return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+		dims = layout.Flex{Axis: layout.Vertical}.Layout(gtx,
 			layout.Rigid(func(gtx C) D {
 				// Mobile header with tabs
 				return g.layoutMobileHeader(gtx)
@@ -326,17 +520,24 @@ func (g *GUI) Layout(gtx C) D {
 				return g.layoutMain(gtx)
 			}),
 		)
+	} else {
+		// Desktop layout
+		dims = layout.Flex{Axis: layout.Horizontal}.Layout(gtx,
+			layout.Rigid(func(gtx C) D {
+				return g.layoutSidebar(gtx)
+			}),
+			layout.Flexed(1, func(gtx C) D {
+				return g.layoutMain(gtx)
+			}),
+		)
 	}
 
-	// Desktop layout
-	return layout.Flex{Axis: layout.Horizontal}.Layout(gtx,
-		layout.Rigid(func(gtx C) D {
-			return g.layoutSidebar(gtx)
-		}),
-		layout.Flexed(1, func(gtx C) D {
-			return g.layoutMain(gtx)
-		}),
-	)
+	// Draw the QR share/import overlay, if open, on top of the main tree.
+	if g.qr.visible {
+		g.qr.Layout(gtx, g)
+	}
+
+	return dims
 }
 
 func (g *GUI) layoutMobileHeader(gtx C) D {
@@ -388,11 +589,14 @@ func (g *GUI) layoutSidebar(gtx C) D {
 						}),
 					)
 				}
+				if g.showOTP || g.showJobs || g.showWatches {
+					return D{}
+				}
 				return g.layoutBotList(gtx)
 			}),
 			// Action Buttons
 			layout.Rigid(func(gtx C) D {
-				if g.showUsers {
+				if g.showUsers || g.showOTP || g.showJobs || g.showWatches {
 					return D{}
 				}
 
@@ -401,7 +605,7 @@ func (g *GUI) layoutSidebar(gtx C) D {
 						if g.saveAllBtn.Clicked(gtx) {
 							g.saveBots()
 							g.logView.Write([]byte("💾 All bots saved successfully\n"))
-							g.w.Invalidate()
+							g.invalidate()
 						}
 
 						btn := material.Button(g.th, &g.saveAllBtn, "💾 Save All Bots")
@@ -415,7 +619,7 @@ func (g *GUI) layoutSidebar(gtx C) D {
 						if g.addBotBtn.Clicked(gtx) {
 							g.addBot()
 							g.logView.Write([]byte("🤖 New bot added\n"))
-							g.w.Invalidate()
+							g.invalidate()
 						}
 
 						btn := material.Button(g.th, &g.addBotBtn, "✚ Add Bot")
@@ -425,6 +629,18 @@ func (g *GUI) layoutSidebar(gtx C) D {
 						btn.TextSize = unit.Sp(14)
 						return btn.Layout(gtx)
 					}),
+					layout.Rigid(func(gtx C) D {
+						if g.importBotBtn.Clicked(gtx) {
+							g.showImportQR("Import bot config from QR", g.importBotConfigPayload)
+						}
+
+						btn := material.Button(g.th, &g.importBotBtn, "📥 Import Bot")
+						btn.Background = purpleAccent
+						btn.Color = bgColor
+						btn.CornerRadius = unit.Dp(8)
+						btn.TextSize = unit.Sp(14)
+						return layout.Inset{Top: unit.Dp(8)}.Layout(gtx, btn.Layout)
+					}),
 				)
 			}),
 		)
@@ -434,13 +650,43 @@ func (g *GUI) layoutSidebar(gtx C) D {
 func (g *GUI) layoutTabButtons(gtx C) D {
 	if g.botsTabBtn.Clicked(gtx) {
 		g.showUsers = false
-		g.w.Invalidate()
+		g.showOTP = false
+		g.showJobs = false
+		g.showWatches = false
+		g.invalidate()
 	}
 
 	if g.usersTabBtn.Clicked(gtx) {
 		g.showUsers = true
+		g.showOTP = false
+		g.showJobs = false
+		g.showWatches = false
 		g.usersView.loadUsers()
-		g.w.Invalidate()
+		g.invalidate()
+	}
+
+	if g.otpTabBtn.Clicked(gtx) {
+		g.showUsers = false
+		g.showOTP = true
+		g.showJobs = false
+		g.showWatches = false
+		g.invalidate()
+	}
+
+	if g.jobsTabBtn.Clicked(gtx) {
+		g.showUsers = false
+		g.showOTP = false
+		g.showJobs = true
+		g.showWatches = false
+		g.invalidate()
+	}
+
+	if g.watchesTabBtn.Clicked(gtx) {
+		g.showUsers = false
+		g.showOTP = false
+		g.showJobs = false
+		g.showWatches = true
+		g.invalidate()
 	}
 
 	minHeight := gtx.Dp(36)
@@ -483,9 +729,17 @@ func (g *GUI) layoutTabButtons(gtx C) D {
 	}
 
 	return layout.Flex{Axis: layout.Horizontal, Spacing: layout.SpaceBetween}.Layout(gtx,
-		layout.Flexed(1, func(gtx C) D { return layoutTab(&g.botsTabBtn, "Bots", !g.showUsers) }),
+		layout.Flexed(1, func(gtx C) D {
+			return layoutTab(&g.botsTabBtn, "Bots", !g.showUsers && !g.showOTP && !g.showJobs && !g.showWatches)
+		}),
 		layout.Rigid(layout.Spacer{Width: unit.Dp(6)}.Layout),
 		layout.Flexed(1, func(gtx C) D { return layoutTab(&g.usersTabBtn, "Accounts", g.showUsers) }),
+		layout.Rigid(layout.Spacer{Width: unit.Dp(6)}.Layout),
+		layout.Flexed(1, func(gtx C) D { return layoutTab(&g.otpTabBtn, "2FA", g.showOTP) }),
+		layout.Rigid(layout.Spacer{Width: unit.Dp(6)}.Layout),
+		layout.Flexed(1, func(gtx C) D { return layoutTab(&g.jobsTabBtn, "Jobs", g.showJobs) }),
+		layout.Rigid(layout.Spacer{Width: unit.Dp(6)}.Layout),
+		layout.Flexed(1, func(gtx C) D { return layoutTab(&g.watchesTabBtn, "Watches", g.showWatches) }),
 	)
 }
 
@@ -524,7 +778,7 @@ func (g *GUI) layoutBotList(gtx C) D {
 
 		g.saveBots()
 		g.logView.Write([]byte(fmt.Sprintf("🗑️ Bot '%s' deleted\n", botName)))
-		g.w.Invalidate()
+		g.invalidate()
 		return D{}
 	}
 
@@ -532,7 +786,7 @@ func (g *GUI) layoutBotList(gtx C) D {
 	for i := 0; i < len(g.bots); i++ {
 		if g.bots[i].selectBtn.Clicked(gtx) {
 			g.selectedBot = i
-			g.w.Invalidate()
+			g.invalidate()
 		}
 	}
 
@@ -647,6 +901,15 @@ func (g *GUI) layoutMain(gtx C) D {
 	if g.showUsers {
 		return g.usersView.Layout(gtx)
 	}
+	if g.showOTP {
+		return g.otpView.Layout(gtx)
+	}
+	if g.showJobs {
+		return g.jobsView.Layout(gtx)
+	}
+	if g.showWatches {
+		return g.watchesView.Layout(gtx)
+	}
 
 	if g.selectedBot < 0 || g.selectedBot >= len(g.bots) {
 		return D{}
@@ -669,6 +932,9 @@ func (g *GUI) layoutMain(gtx C) D {
 						gtx.Constraints.Min.Y = gtx.Dp(400)
 						return g.layoutBotConfig(gtx, bot)
 					}),
+					layout.Rigid(func(gtx C) D {
+						return g.layoutMetrics(gtx, bot)
+					}),
 					layout.Flexed(1, func(gtx C) D {
 						// Logs section with minimum height
 						gtx.Constraints.Min.Y = gtx.Dp(300)
@@ -696,13 +962,30 @@ func (g *GUI) layoutHeader(gtx C, bot *Bot) D {
 				}
 				return label.Layout(gtx)
 			}),
+			layout.Rigid(func(gtx C) D {
+				if bot.shareBtn.Clicked(gtx) {
+					g.shareBotConfig(bot.config)
+				}
+
+				btn := material.Button(g.th, &bot.shareBtn, "📤 Share")
+				btn.Background = purpleAccent
+				btn.Color = bgColor
+				btn.CornerRadius = unit.Dp(8)
+				if g.isMobile {
+					btn.TextSize = unit.Sp(13)
+				}
+				return layout.Inset{Right: unit.Dp(12)}.Layout(gtx, btn.Layout)
+			}),
 			layout.Rigid(func(gtx C) D {
 				if bot.runBtn.Clicked(gtx) {
 					if bot.config.IsRunning {
 						bot.cancel()
+						if android.Supported() {
+							android.Default.CancelWake(bot.config.ID)
+						}
 						bot.config.IsRunning = false
 						g.logView.Write([]byte(fmt.Sprintf("⏹️ Bot '%s' stopped\n", bot.nameEditor.Value())))
-						g.w.Invalidate()
+						g.invalidate()
 					} else {
 						g.startBot(bot)
 					}
@@ -761,6 +1044,9 @@ func (g *GUI) layoutBotConfig(gtx C, bot *Bot) D {
 					layout.Rigid(func(gtx C) D {
 						return g.layoutFormRow(gtx, "🔑 Pre-Sale Code", &bot.preSaleEditor)
 					}),
+					layout.Rigid(func(gtx C) D {
+						return g.layoutFormRow(gtx, "🔐 2FA Site (enrolled in the 2FA tab)", &bot.totpSiteEditor)
+					}),
 					layout.Rigid(func(gtx C) D {
 						return g.layoutFormRow(gtx, "📍 Area Filter", &bot.filterEditor)
 					}),
@@ -954,6 +1240,30 @@ func (g *GUI) layoutLogs(gtx C) D {
 }
 
 // Updated startBot function to extract values from input fields
+// runScheduledJob is the scheduler.Handler wired into g.scheduler: it
+// turns a scheduler.Job's Payload into a ScraperConfig and runs it through
+// services.RunScraper, returning RunScraper's error so a scheduled job's
+// "last result" reflects a real failure.
+func (g *GUI) runScheduledJob(ctx context.Context, job scheduler.Job) error {
+	cfg := services.ScraperConfig{
+		BaseURL: job.Site,
+	}
+	if eventID, ok := job.Payload["event_id"].(string); ok {
+		cfg.EventID = eventID
+	}
+	if ticketID, ok := job.Payload["ticket_id"].(string); ok {
+		cfg.TicketID = ticketID
+	}
+	if filter, ok := job.Payload["filter"].(string); ok {
+		cfg.Filter = filter
+	}
+	if sessionID, ok := job.Payload["session_id"].(string); ok {
+		cfg.SessionID = sessionID
+	}
+
+	return services.RunScraper(ctx, cfg)
+}
+
 func (g *GUI) startBot(bot *Bot) {
 	ctx, cancel := context.WithCancel(context.Background())
 	bot.cancel = cancel
@@ -966,6 +1276,7 @@ func (g *GUI) startBot(bot *Bot) {
 	bot.config.Quantity = bot.quantityEditor.Value()
 	bot.config.MaxTickets = bot.maxTicketsEditor.Value()
 	bot.config.PreSaleCode = bot.preSaleEditor.Value()
+	bot.config.TOTPSite = bot.totpSiteEditor.Value()
 	bot.config.Schedule = bot.scheduleCheckbox.Value
 	bot.config.StartDate = bot.dateEditor.Value()
 	bot.config.StartTime = fmt.Sprintf("%s:%s", bot.hourEditor.Value(), bot.minuteEditor.Value())
@@ -978,12 +1289,14 @@ func (g *GUI) startBot(bot *Bot) {
 	}
 
 	bot.config.IsRunning = true
-	g.w.Invalidate()
+	bot.metrics = newBotMetrics(g)
+	g.invalidate()
 
 	go func() {
 		defer func() {
+			bot.metrics.Stop()
 			bot.config.IsRunning = false
-			g.w.Invalidate()
+			g.invalidate()
 			g.logView.Write([]byte("🛑 Bot stopped\n"))
 		}()
 
@@ -1001,6 +1314,11 @@ func (g *GUI) startBot(bot *Bot) {
 
 		// Handle scheduling
 		if bot.config.Schedule {
+			if android.Supported() {
+				if err := g.scheduleExactWake(bot); err != nil {
+					g.logView.Write([]byte(fmt.Sprintf("❌ Schedule error: %v\n", err)))
+				}
+			}
 			if err := waitForScheduledTime(ctx, bot.config.StartDate, bot.config.StartTime, bot.config.Name, g); err != nil {
 				g.logView.Write([]byte(fmt.Sprintf("❌ Schedule error: %v\n", err)))
 				return
@@ -1033,6 +1351,9 @@ func (g *GUI) startBot(bot *Bot) {
 			PreSaleCode:    bot.config.PreSaleCode,
 			SessionID:      bot.config.SID,
 			Loop:           bot.config.Loop,
+			Metrics:        bot.metrics,
+			TOTP:           g.totp,
+			TOTPSite:       bot.config.TOTPSite,
 		}
 
 		g.logView.Write([]byte(fmt.Sprintf("🚀 Starting bot: %s\n", bot.config.Name)))
@@ -1040,6 +1361,25 @@ func (g *GUI) startBot(bot *Bot) {
 	}()
 }
 
+// scheduleExactWake hands bot's scheduled start off to android.Default, so
+// AlarmManager can wake the app under Doze even if this process is killed
+// while waitForScheduledTime would otherwise be sleeping. The BotConfig
+// JSON is reused verbatim as the payload persisted for the alarm receiver.
+func (g *GUI) scheduleExactWake(bot *Bot) error {
+	loc := time.Local
+	fireAt, err := time.ParseInLocation("2006-01-02 15:04", fmt.Sprintf("%s %s", bot.config.StartDate, bot.config.StartTime), loc)
+	if err != nil {
+		return fmt.Errorf("invalid datetime format: %s %s", bot.config.StartDate, bot.config.StartTime)
+	}
+
+	payload, err := json.Marshal(bot.config)
+	if err != nil {
+		return fmt.Errorf("marshal bot config: %w", err)
+	}
+
+	return android.Default.ScheduleExactWake(bot.config.ID, fireAt, payload)
+}
+
 // waitForScheduledTime waits until the scheduled datetime
 // waitForScheduledTime waits until the scheduled datetime
 func waitForScheduledTime(ctx context.Context, startDate, startTime, botName string, gui *GUI) error {
@@ -1094,6 +1434,7 @@ func (g *GUI) saveBots() {
 			StartDate:   bot.dateEditor.Value(),
 			StartTime:   fmt.Sprintf("%s:%s", bot.hourEditor.Value(), bot.minuteEditor.Value()),
 			Loop:        bot.loopCheckbox.Value,
+			TOTPSite:    bot.totpSiteEditor.Value(),
 		}
 	}
 
@@ -1103,16 +1444,17 @@ func (g *GUI) saveBots() {
 		return
 	}
 
-	if err := os.WriteFile("data/bots_config.json", data, 0644); err != nil {
+	if err := os.WriteFile(g.botsConfigPath(), data, 0644); err != nil {
 		g.logView.Write([]byte(fmt.Sprintf("❌ Error saving bots: %v\n", err)))
 		return
 	}
+	g.markSelfWrite()
 
 	g.logView.Write([]byte("💾 Bots saved successfully\n"))
 }
 
 func (g *GUI) loadBots() {
-	data, err := os.ReadFile("data/bots_config.json")
+	data, err := os.ReadFile(g.botsConfigPath())
 	if err != nil {
 		if !os.IsNotExist(err) {
 			g.logView.Write([]byte(fmt.Sprintf("❌ Error loading bots: %v\n", err)))
@@ -1127,130 +1469,81 @@ func (g *GUI) loadBots() {
 	}
 
 	for _, cfg := range configs {
-		bot := &Bot{
-			config: cfg,
-		}
-
-		// Initialize text fields with values
-		bot.nameEditor = TextField{Editor: widget.Editor{SingleLine: true}}
-		bot.eventIDEditor = TextField{Editor: widget.Editor{SingleLine: true}}
-		bot.ticketIDEditor = TextField{Editor: widget.Editor{SingleLine: true}}
-		bot.filterEditor = TextField{Editor: widget.Editor{SingleLine: true}}
-		bot.quantityEditor = TextField{Editor: widget.Editor{SingleLine: true}}
-		bot.maxTicketsEditor = TextField{Editor: widget.Editor{SingleLine: true}}
-		bot.preSaleEditor = TextField{Editor: widget.Editor{SingleLine: true}}
-		bot.dateEditor = TextField{Editor: widget.Editor{SingleLine: true}}
-		bot.hourEditor = TextField{Editor: widget.Editor{SingleLine: true}}
-		bot.minuteEditor = TextField{Editor: widget.Editor{SingleLine: true}}
-
-		bot.nameEditor.SetValue(cfg.Name)
-		bot.eventIDEditor.SetValue(cfg.EventID)
-		bot.ticketIDEditor.SetValue(cfg.TicketID)
-		bot.filterEditor.SetValue(cfg.Filter)
-		bot.quantityEditor.SetValue(cfg.Quantity)
-		bot.maxTicketsEditor.SetValue(cfg.MaxTickets)
-		bot.preSaleEditor.SetValue(cfg.PreSaleCode)
-		bot.dateEditor.SetValue(cfg.StartDate)
-
-		// Parse time components
-		if cfg.StartTime != "" {
-			parts := strings.Split(cfg.StartTime, ":")
-			if len(parts) >= 2 {
-				bot.hourEditor.SetValue(parts[0])
-				bot.minuteEditor.SetValue(parts[1])
-			}
-		}
-
-		bot.scheduleCheckbox.Value = cfg.Schedule
-		bot.loopCheckbox.Value = cfg.Loop
-
-		g.bots = append(g.bots, bot)
+		g.bots = append(g.bots, newBotFromConfig(cfg))
 	}
 }
 
-// UsersView methods with FIXED file operations
-func (uv *UsersView) loadUsers() {
-	uv.mu.Lock()
-	defer uv.mu.Unlock()
-
-	uv.users = []User{}
-	uv.deleteButtons = []widget.Clickable{}
+// newBotFromConfig builds a Bot with its editors initialized from cfg,
+// matching the "blank editor struct, then SetValue per field" pattern used
+// everywhere a Bot is constructed from a saved or imported config.
+func newBotFromConfig(cfg BotConfig) *Bot {
+	bot := &Bot{config: cfg, metricsPanel: newMetricsPanel()}
 
-	// Ensure data directory exists
-	if err := os.MkdirAll("data", 0755); err != nil {
-		uv.gui.logView.Write([]byte(fmt.Sprintf("❌ Error creating data directory: %v\n", err)))
-		return
-	}
-
-	filename := "data/users.json"
+	bot.nameEditor = TextField{Editor: widget.Editor{SingleLine: true}}
+	bot.eventIDEditor = TextField{Editor: widget.Editor{SingleLine: true}}
+	bot.ticketIDEditor = TextField{Editor: widget.Editor{SingleLine: true}}
+	bot.filterEditor = TextField{Editor: widget.Editor{SingleLine: true}}
+	bot.quantityEditor = TextField{Editor: widget.Editor{SingleLine: true}}
+	bot.maxTicketsEditor = TextField{Editor: widget.Editor{SingleLine: true}}
+	bot.preSaleEditor = TextField{Editor: widget.Editor{SingleLine: true}}
+	bot.totpSiteEditor = TextField{Editor: widget.Editor{SingleLine: true}}
+	bot.dateEditor = TextField{Editor: widget.Editor{SingleLine: true}}
+	bot.hourEditor = TextField{Editor: widget.Editor{SingleLine: true}}
+	bot.minuteEditor = TextField{Editor: widget.Editor{SingleLine: true}}
 
-	// Check if file exists first
-	if _, err := os.Stat(filename); os.IsNotExist(err) {
-		// File doesn't exist, create empty array
-		emptyData, _ := json.MarshalIndent([]User{}, "", "  ")
-		if err := os.WriteFile(filename, emptyData, 0644); err != nil {
-			uv.gui.logView.Write([]byte(fmt.Sprintf("❌ Error creating users file: %v\n", err)))
+	bot.nameEditor.SetValue(cfg.Name)
+	bot.eventIDEditor.SetValue(cfg.EventID)
+	bot.ticketIDEditor.SetValue(cfg.TicketID)
+	bot.filterEditor.SetValue(cfg.Filter)
+	bot.quantityEditor.SetValue(cfg.Quantity)
+	bot.maxTicketsEditor.SetValue(cfg.MaxTickets)
+	bot.preSaleEditor.SetValue(cfg.PreSaleCode)
+	bot.totpSiteEditor.SetValue(cfg.TOTPSite)
+	bot.dateEditor.SetValue(cfg.StartDate)
+
+	// Parse time components
+	if cfg.StartTime != "" {
+		parts := strings.Split(cfg.StartTime, ":")
+		if len(parts) >= 2 {
+			bot.hourEditor.SetValue(parts[0])
+			bot.minuteEditor.SetValue(parts[1])
 		}
-		return
 	}
 
-	data, err := os.ReadFile(filename)
-	if err != nil {
-		uv.gui.logView.Write([]byte(fmt.Sprintf("❌ Error reading users file: %v\n", err)))
-		return
-	}
+	bot.scheduleCheckbox.Value = cfg.Schedule
+	bot.loopCheckbox.Value = cfg.Loop
 
-	// Check if file is empty
-	if len(data) == 0 {
-		return
-	}
+	return bot
+}
 
+// loadUsers re-reads the encrypted vault. The vault must already be
+// unlocked (via the passphrase modal); most callers don't need to call this
+// directly since unlockVault populates uv.users on startup.
+func (uv *UsersView) loadUsers() {
 	var users []User
-	if err := json.Unmarshal(data, &users); err != nil {
-		// If file is corrupted, create a fresh one
-		uv.gui.logView.Write([]byte(fmt.Sprintf("❌ Error parsing users file, creating fresh one: %v\n", err)))
-		emptyData, _ := json.MarshalIndent([]User{}, "", "  ")
-		if err := os.WriteFile(filename, emptyData, 0644); err != nil {
-			uv.gui.logView.Write([]byte(fmt.Sprintf("❌ Error creating fresh users file: %v\n", err)))
-		}
+	if err := uv.gui.vault.Load(&users); err != nil {
+		uv.gui.logView.Write([]byte(fmt.Sprintf("❌ Error loading vault: %v\n", err)))
 		return
 	}
 
+	uv.mu.Lock()
 	uv.users = users
+	uv.deleteButtons = make([]widget.Clickable, len(users))
+	uv.mu.Unlock()
 }
 
+// saveUsers re-encrypts uv.users and writes them to the vault via a
+// temp-file-then-rename, same as the store's usual atomic-write pattern.
 func (uv *UsersView) saveUsers() {
 	uv.mu.Lock()
-	defer uv.mu.Unlock()
-
-	// Ensure data directory exists
-	if err := os.MkdirAll("data", 0755); err != nil {
-		uv.gui.logView.Write([]byte(fmt.Sprintf("❌ Error creating data directory: %v\n", err)))
-		return
-	}
-
-	data, err := json.MarshalIndent(uv.users, "", "  ")
-	if err != nil {
-		uv.gui.logView.Write([]byte(fmt.Sprintf("❌ Error marshalling users: %v\n", err)))
-		return
-	}
-
-	// Write to temporary file first
-	tempFilename := "data/users.json.tmp"
-	filename := "data/users.json"
-
-	if err := os.WriteFile(tempFilename, data, 0644); err != nil {
-		uv.gui.logView.Write([]byte(fmt.Sprintf("❌ Error writing temp users file: %v\n", err)))
-		return
-	}
+	users := uv.users
+	uv.mu.Unlock()
 
-	// Atomic rename
-	if err := os.Rename(tempFilename, filename); err != nil {
-		uv.gui.logView.Write([]byte(fmt.Sprintf("❌ Error renaming temp users file: %v\n", err)))
-		// Try to clean up temp file
-		os.Remove(tempFilename)
+	if err := uv.gui.vault.Save(&users); err != nil {
+		uv.gui.logView.Write([]byte(fmt.Sprintf("❌ Error saving vault: %v\n", err)))
 		return
 	}
+	uv.gui.markSelfWrite()
 
 	uv.gui.logView.Write([]byte("✅ Users saved successfully\n"))
 }
@@ -1263,6 +1556,18 @@ func (uv *UsersView) Layout(gtx C) D {
 		return D{}
 	}
 
+	// Handle lock button (without lock)
+	if uv.lockBtn.Clicked(gtx) {
+		uv.gui.lockVault()
+		return D{}
+	}
+
+	// Handle import-from-QR button (without lock)
+	if uv.importBtn.Clicked(gtx) {
+		uv.gui.showImportQR("Import SID from QR", uv.gui.importSIDPayload)
+		return D{}
+	}
+
 	// Handle delete all button (without lock)
 	if uv.deleteAllBtn.Clicked(gtx) {
 		uv.mu.Lock()
@@ -1331,7 +1636,7 @@ func (uv *UsersView) Layout(gtx C) D {
 				uv.gui.w.Invalidate()
 			}()
 
-			username, err := services.GetUserName(sid)
+			username, err := services.GetUserName(sid, nil)
 
 			uv.mu.Lock()
 			var message string
@@ -1378,6 +1683,9 @@ func (uv *UsersView) Layout(gtx C) D {
 	if len(uv.deleteButtons) != len(uv.users) {
 		uv.deleteButtons = make([]widget.Clickable, len(uv.users))
 	}
+	if len(uv.shareButtons) != len(uv.users) {
+		uv.shareButtons = make([]widget.Clickable, len(uv.users))
+	}
 	// Use a scrollable layout for users view
 	list := &widget.List{
 		List: layout.List{Axis: layout.Vertical},
@@ -1411,6 +1719,16 @@ func (uv *UsersView) Layout(gtx C) D {
 							}
 							return layout.Inset{Right: unit.Dp(12)}.Layout(gtx, btn.Layout)
 						}),
+						layout.Rigid(func(gtx C) D {
+							btn := material.Button(uv.gui.th, &uv.importBtn, "📥 Import from QR")
+							btn.Background = purpleAccent
+							btn.Color = bgColor
+							btn.CornerRadius = unit.Dp(8)
+							if uv.gui.isMobile {
+								btn.TextSize = unit.Sp(13)
+							}
+							return layout.Inset{Right: unit.Dp(12)}.Layout(gtx, btn.Layout)
+						}),
 						layout.Rigid(func(gtx C) D {
 							if len(uv.users) == 0 {
 								return D{}
@@ -1422,6 +1740,16 @@ func (uv *UsersView) Layout(gtx C) D {
 							if uv.gui.isMobile {
 								btn.TextSize = unit.Sp(13)
 							}
+							return layout.Inset{Right: unit.Dp(12)}.Layout(gtx, btn.Layout)
+						}),
+						layout.Rigid(func(gtx C) D {
+							btn := material.Button(uv.gui.th, &uv.lockBtn, "🔒 Lock")
+							btn.Background = purpleAccent
+							btn.Color = bgColor
+							btn.CornerRadius = unit.Dp(8)
+							if uv.gui.isMobile {
+								btn.TextSize = unit.Sp(13)
+							}
 							return btn.Layout(gtx)
 						}),
 					)
@@ -1558,6 +1886,20 @@ func (uv *UsersView) layoutUserCard(gtx C, index int) D {
 							}
 							return label.Layout(gtx)
 						}),
+						layout.Rigid(func(gtx C) D {
+							if index >= len(uv.shareButtons) {
+								return D{}
+							}
+							if uv.shareButtons[index].Clicked(gtx) {
+								uv.gui.shareUserSID(user)
+							}
+							btn := material.Button(uv.gui.th, &uv.shareButtons[index], "📤 Share")
+							btn.Background = purpleAccent
+							btn.Color = bgColor
+							btn.CornerRadius = unit.Dp(6)
+							btn.TextSize = unit.Sp(12)
+							return layout.Inset{Right: unit.Dp(8)}.Layout(gtx, btn.Layout)
+						}),
 						layout.Rigid(func(gtx C) D {
 							if index >= len(uv.deleteButtons) {
 								return D{}
@@ -1665,35 +2007,210 @@ func (g *GUI) layoutUserDropdown(gtx C, bot *Bot) D {
 	})
 }
 
+// logLevelFilters are the thresholds offered by the level dropdown, in the
+// same order as their LogLevel ordinal so selected can be compared directly
+// against entry.Level.
+var logLevelFilters = []string{"All levels", "Info+", "Warn+", "Error only"}
+
+const allBotsFilter = "All bots"
+
+// LogView is the GUI's LogSink: it keeps every LogEntry published on the
+// bus in a ring buffer and re-slices it per Layout according to the filter
+// bar (bot, level threshold, substring search) without touching the buffer
+// itself, so the underlying history survives changing a filter.
 type LogView struct {
 	gui   *GUI
 	list  widget.List
-	logs  []string
+	ring  *logbus.LogRingBuffer
 	dirty bool
 	mu    sync.Mutex
+	// dropped counts entries the ring buffer has overwritten since the last
+	// Layout, so layoutList can shift list.Position.First by the same
+	// amount and keep the viewport on the same entries across truncation.
+	dropped int
+
+	botFilter   Dropdown
+	levelFilter Dropdown
+	search      TextField
+	saveLogsBtn widget.Clickable
 }
 
+// Write is the legacy io.Writer entry point used throughout the GUI
+// (g.logView.Write([]byte(...))) and by BotLogWriter. It recovers a
+// LogEntry from the free-text line and publishes it on the bus so every
+// sink (this list, the per-bot file, the JSON-lines file) sees it.
 func (l *LogView) Write(p []byte) (n int, err error) {
-	l.mu.Lock()
-	defer l.mu.Unlock()
+	entry := logbus.ParseEntry(p)
+	if l.gui != nil && l.gui.logBus != nil {
+		l.gui.logBus.Publish(entry)
+	} else {
+		l.Publish(entry)
+	}
+	return len(p), nil
+}
 
-	l.logs = append(l.logs, time.Now().Format("15:04:05")+" "+string(p))
+// Publish implements logbus.LogSink: it appends entry to the ring buffer.
+// Other sinks (file, JSON-lines) are reached via the LogBus, not here.
+func (l *LogView) Publish(entry logbus.LogEntry) error {
+	if l.ring == nil {
+		l.ring = logbus.NewLogRingBuffer(logbus.DefaultRingCapacity)
+	}
+	overwrote := l.ring.Add(entry)
+
+	l.mu.Lock()
+	if overwrote {
+		l.dropped++
+	}
 	l.dirty = true
+	l.mu.Unlock()
+
 	if l.gui != nil && l.gui.w != nil {
 		l.gui.w.Invalidate()
 	}
-	return len(p), nil
+	return nil
+}
+
+// Snapshot returns every entry currently held in the ring buffer, oldest
+// first.
+func (l *LogView) Snapshot() []logbus.LogEntry {
+	if l.ring == nil {
+		return nil
+	}
+	return l.ring.Snapshot()
+}
+
+// ExportTo writes the current snapshot to w in one of logbus.FormatPlain,
+// logbus.FormatJSONLines or logbus.FormatCSV.
+func (l *LogView) ExportTo(w io.Writer, format string) error {
+	return logbus.ExportEntries(w, format, l.Snapshot())
 }
 
 func (l *LogView) Layout(gtx C) D {
-	l.mu.Lock()
-	defer l.mu.Unlock()
+	if l.saveLogsBtn.Clicked(gtx) {
+		l.saveSnapshotToDisk()
+	}
+
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+		layout.Rigid(l.layoutFilterBar),
+		layout.Flexed(1, l.layoutList),
+	)
+}
+
+// saveSnapshotToDisk backs the "Save logs…" button: it writes the current
+// ring buffer to a timestamped plain-text file under configDir/logs/exports,
+// the same place the rotating file sinks live, and reports the path (or
+// any error) back through the log pipeline itself.
+func (l *LogView) saveSnapshotToDisk() {
+	if l.gui == nil || l.gui.logBus == nil {
+		return
+	}
+
+	dir := filepath.Join(l.gui.configDir, "logs", "exports")
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		l.gui.logBus.Errorf("", "Could not create log export directory: %v", err)
+		return
+	}
+
+	path := filepath.Join(dir, fmt.Sprintf("logs-%s.txt", time.Now().Format("20060102-150405")))
+	f, err := os.Create(path)
+	if err != nil {
+		l.gui.logBus.Errorf("", "Could not save logs: %v", err)
+		return
+	}
+	defer f.Close()
+
+	if err := l.ExportTo(f, logbus.FormatPlain); err != nil {
+		l.gui.logBus.Errorf("", "Could not write log export: %v", err)
+		return
+	}
+	l.gui.logBus.Infof("", "Saved logs to %s", path)
+}
+
+func (l *LogView) layoutFilterBar(gtx C) D {
+	if len(l.levelFilter.Options) == 0 {
+		l.levelFilter.Options = logLevelFilters
+	}
+	l.rebuildBotOptions()
+
+	return layout.Inset{Bottom: unit.Dp(8)}.Layout(gtx, func(gtx C) D {
+		return layout.Flex{Axis: layout.Horizontal, Alignment: layout.Middle}.Layout(gtx,
+			layout.Flexed(1, func(gtx C) D {
+				return layout.Inset{Right: unit.Dp(8)}.Layout(gtx, func(gtx C) D {
+					return l.botFilter.Layout(gtx, l.gui.th)
+				})
+			}),
+			layout.Flexed(1, func(gtx C) D {
+				return layout.Inset{Right: unit.Dp(8)}.Layout(gtx, func(gtx C) D {
+					return l.levelFilter.Layout(gtx, l.gui.th)
+				})
+			}),
+			layout.Flexed(2, func(gtx C) D {
+				ed := material.Editor(l.gui.th, &l.search.Editor, "Search logs...")
+				ed.Color = textColor
+				ed.HintColor = disabledColor
+				return widget.Border{
+					Color:        borderColor,
+					Width:        unit.Dp(1),
+					CornerRadius: unit.Dp(8),
+				}.Layout(gtx, func(gtx C) D {
+					return layout.UniformInset(unit.Dp(10)).Layout(gtx, ed.Layout)
+				})
+			}),
+			layout.Rigid(func(gtx C) D {
+				btn := material.Button(l.gui.th, &l.saveLogsBtn, "💾 Save logs…")
+				btn.Background = purpleAccent
+				btn.Color = bgColor
+				btn.CornerRadius = unit.Dp(8)
+				btn.TextSize = unit.Sp(13)
+				return btn.Layout(gtx)
+			}),
+		)
+	})
+}
+
+// rebuildBotOptions keeps the per-bot dropdown's Options in sync with the
+// currently configured bots, preserving the current selection by name.
+func (l *LogView) rebuildBotOptions() {
+	names := []string{allBotsFilter}
+	for _, bot := range l.gui.bots {
+		names = append(names, bot.config.Name)
+	}
+
+	var current string
+	if l.botFilter.selected < len(l.botFilter.Options) {
+		current = l.botFilter.Options[l.botFilter.selected]
+	}
+	l.botFilter.Options = names
+	l.botFilter.selected = 0
+	for i, name := range names {
+		if name == current {
+			l.botFilter.selected = i
+			break
+		}
+	}
+}
+
+func (l *LogView) layoutList(gtx C) D {
+	total := l.Snapshot()
+	filtered := l.filterEntries(total)
 
+	l.mu.Lock()
+	if l.dropped > 0 {
+		// The ring buffer dropped l.dropped oldest entries since the last
+		// layout: every remaining item shifted down by that many indices,
+		// so shift the viewport to match instead of yanking it to the top.
+		l.list.Position.First -= l.dropped
+		if l.list.Position.First < 0 {
+			l.list.Position.First = 0
+		}
+		l.dropped = 0
+	}
 	if l.dirty {
-		l.list.Position.First = len(l.logs) - 1
+		l.list.Position.First = len(filtered) - 1
 		l.list.Position.Offset = 1000000
 		l.dirty = false
 	}
+	l.mu.Unlock()
 
 	l.list.Axis = layout.Vertical
 
@@ -1705,31 +2222,207 @@ func (l *LogView) Layout(gtx C) D {
 		defer clip.UniformRRect(image.Rectangle{Max: gtx.Constraints.Max}, gtx.Dp(unit.Dp(8))).Push(gtx.Ops).Pop()
 		paint.Fill(gtx.Ops, color.NRGBA{R: 18, G: 20, B: 28, A: 255})
 
-		if len(l.logs) == 0 {
+		if len(filtered) == 0 {
+			msg := "No logs yet..."
+			if len(total) > 0 {
+				msg = "No logs match the current filter"
+			}
 			return layout.Center.Layout(gtx, func(gtx C) D {
-				label := material.Body2(l.gui.th, "No logs yet...")
+				label := material.Body2(l.gui.th, msg)
 				label.Color = disabledColor
 				return label.Layout(gtx)
 			})
 		}
 
-		return material.List(l.gui.th, &l.list).Layout(gtx, len(l.logs), func(gtx C, i int) D {
-			return layout.UniformInset(unit.Dp(8)).Layout(gtx, func(gtx C) D {
-				label := material.Body2(l.gui.th, l.logs[i])
-				label.Color = textColor
-				label.TextSize = unit.Sp(12)
-				return label.Layout(gtx)
-			})
+		return material.List(l.gui.th, &l.list).Layout(gtx, len(filtered), func(gtx C, i int) D {
+			return l.layoutEntry(gtx, filtered[i])
 		})
 	})
 }
 
+// filterEntries re-slices entries per the current filter bar state. The
+// filter widgets are only ever touched from the GUI goroutine that calls
+// Layout, so this needs no locking of its own.
+func (l *LogView) filterEntries(entries []logbus.LogEntry) []logbus.LogEntry {
+	botName := ""
+	if l.botFilter.selected > 0 && l.botFilter.selected < len(l.botFilter.Options) {
+		botName = l.botFilter.Options[l.botFilter.selected]
+	}
+	minLevel := logbus.LogLevel(l.levelFilter.selected)
+	query := strings.ToLower(strings.TrimSpace(l.search.Value()))
+
+	filtered := make([]logbus.LogEntry, 0, len(entries))
+	for _, entry := range entries {
+		if botName != "" && entry.BotName != botName {
+			continue
+		}
+		if entry.Level < minLevel {
+			continue
+		}
+		if query != "" && !strings.Contains(strings.ToLower(entry.Message), query) {
+			continue
+		}
+		filtered = append(filtered, entry)
+	}
+	return filtered
+}
+
+func (l *LogView) layoutEntry(gtx C, entry logbus.LogEntry) D {
+	return layout.UniformInset(unit.Dp(8)).Layout(gtx, func(gtx C) D {
+		children := []layout.FlexChild{
+			layout.Rigid(func(gtx C) D {
+				return layout.Inset{Right: unit.Dp(8)}.Layout(gtx, func(gtx C) D {
+					return l.layoutBadge(gtx, entry.Level)
+				})
+			}),
+			layout.Rigid(func(gtx C) D {
+				ts := material.Caption(l.gui.th, entry.Timestamp.Format("15:04:05"))
+				ts.Color = disabledColor
+				return layout.Inset{Right: unit.Dp(8)}.Layout(gtx, ts.Layout)
+			}),
+		}
+		if entry.BotName != "" {
+			children = append(children, layout.Rigid(func(gtx C) D {
+				return layout.Inset{Right: unit.Dp(8)}.Layout(gtx, func(gtx C) D {
+					return l.layoutBotBadge(gtx, entry.BotName)
+				})
+			}))
+		}
+		children = append(children, layout.Flexed(1, func(gtx C) D {
+			return l.layoutMessage(gtx, entry)
+		}))
+		return layout.Flex{Axis: layout.Horizontal, Alignment: layout.Middle}.Layout(gtx, children...)
+	})
+}
+
+// layoutMessage renders entry.Message plain when it carries no ANSI
+// styling, or as a row of independently-colored/weighted spans when it
+// does — so bots that print via fatih/color or logrus show up styled
+// instead of with literal "\x1b[31m" garbage in the line.
+func (l *LogView) layoutMessage(gtx C, entry logbus.LogEntry) D {
+	if len(entry.Spans) == 0 {
+		label := material.Body2(l.gui.th, entry.Message)
+		label.Color = textColor
+		label.TextSize = unit.Sp(12)
+		return label.Layout(gtx)
+	}
+
+	children := make([]layout.FlexChild, len(entry.Spans))
+	for i, span := range entry.Spans {
+		span := span
+		children[i] = layout.Rigid(func(gtx C) D {
+			return l.layoutSpan(gtx, span)
+		})
+	}
+	return layout.Flex{Axis: layout.Horizontal}.Layout(gtx, children...)
+}
+
+func (l *LogView) layoutSpan(gtx C, span logbus.StyledSpan) D {
+	label := material.Body2(l.gui.th, span.Text)
+	label.TextSize = unit.Sp(12)
+	label.Color = textColor
+	if span.HasFG {
+		label.Color = span.FG
+	}
+	if span.Bold {
+		label.Font.Weight = font.Bold
+	}
+
+	macro := op.Record(gtx.Ops)
+	dims := label.Layout(gtx)
+	call := macro.Stop()
+
+	if span.HasBG {
+		paint.FillShape(gtx.Ops, span.BG, clip.Rect{Max: dims.Size}.Op())
+	}
+	call.Add(gtx.Ops)
+
+	if span.Underline {
+		underline := clip.Rect{Min: image.Pt(0, dims.Size.Y-1), Max: dims.Size}
+		paint.FillShape(gtx.Ops, label.Color, underline.Op())
+	}
+
+	return dims
+}
+
+// layoutBotBadge draws botName as a small pill in its logbus.BotColor, so
+// the same bot is visually identifiable across a mixed log stream from
+// several concurrent bots.
+func (l *LogView) layoutBotBadge(gtx C, botName string) D {
+	macro := op.Record(gtx.Ops)
+	dims := layout.UniformInset(unit.Dp(4)).Layout(gtx, func(gtx C) D {
+		label := material.Caption(l.gui.th, botName)
+		label.Color = bgColor
+		label.TextSize = unit.Sp(10)
+		return label.Layout(gtx)
+	})
+	call := macro.Stop()
+
+	rrect := clip.UniformRRect(image.Rectangle{Max: dims.Size}, gtx.Dp(unit.Dp(4)))
+	paint.FillShape(gtx.Ops, logbus.BotColor(botName), rrect.Op(gtx.Ops))
+	call.Add(gtx.Ops)
+
+	return dims
+}
+
+// layoutBadge draws entry's level as a small colored pill, using a
+// distinct op.ColorOp per level (via paint.FillShape) rather than a single
+// textColor for the whole line.
+func (l *LogView) layoutBadge(gtx C, level logbus.LogLevel) D {
+	macro := op.Record(gtx.Ops)
+	dims := layout.UniformInset(unit.Dp(4)).Layout(gtx, func(gtx C) D {
+		label := material.Caption(l.gui.th, level.String())
+		label.Color = bgColor
+		label.TextSize = unit.Sp(10)
+		return label.Layout(gtx)
+	})
+	call := macro.Stop()
+
+	rrect := clip.UniformRRect(image.Rectangle{Max: dims.Size}, gtx.Dp(unit.Dp(4)))
+	paint.FillShape(gtx.Ops, levelColor(level), rrect.Op(gtx.Ops))
+	call.Add(gtx.Ops)
+
+	return dims
+}
+
+func levelColor(level logbus.LogLevel) color.NRGBA {
+	switch level {
+	case logbus.LevelWarn:
+		return runningColor
+	case logbus.LevelError:
+		return dangerColor
+	case logbus.LevelDebug:
+		return disabledColor
+	default:
+		return accentColor
+	}
+}
+
+// BotLogWriter adapts a bot's plain-text log output (services.SetGUIWriter,
+// log.SetOutput) into the structured pipeline. Write still parses the
+// emoji-tagged lines the rest of the codebase already produces; Infof,
+// Warnf, Errorf and Debugf let new call sites publish a LogEntry directly
+// instead of relying on that heuristic.
 type BotLogWriter struct {
 	gui     *GUI
 	botName string
 }
 
 func (w *BotLogWriter) Write(p []byte) (n int, err error) {
-	message := fmt.Sprintf("[%s] %s", w.botName, string(p))
-	return w.gui.logView.Write([]byte(message))
+	entry := logbus.ParseEntry([]byte(fmt.Sprintf("[%s] %s", w.botName, string(p))))
+	w.gui.logBus.Publish(entry)
+	return len(p), nil
+}
+
+func (w *BotLogWriter) Infof(format string, args ...any) {
+	w.gui.logBus.Infof(w.botName, format, args...)
+}
+func (w *BotLogWriter) Warnf(format string, args ...any) {
+	w.gui.logBus.Warnf(w.botName, format, args...)
+}
+func (w *BotLogWriter) Errorf(format string, args ...any) {
+	w.gui.logBus.Errorf(w.botName, format, args...)
+}
+func (w *BotLogWriter) Debugf(format string, args ...any) {
+	w.gui.logBus.Debugf(w.botName, format, args...)
 }
@@ -0,0 +1,449 @@
+package gui
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"tix-scraper/internal/services"
+
+	"gioui.org/f32"
+	"gioui.org/io/event"
+	"gioui.org/io/pointer"
+	"gioui.org/layout"
+	"gioui.org/op"
+	"gioui.org/op/clip"
+	"gioui.org/op/paint"
+	"gioui.org/unit"
+	"gioui.org/widget"
+	"gioui.org/widget/material"
+)
+
+const (
+	metricsRingSize       = 120
+	metricsSampleInterval = time.Second
+)
+
+// metricsSample is one second's worth of aggregated scraper counters.
+type metricsSample struct {
+	AttemptsPerSec float64
+	Success        int
+	Errors         int
+	AvgLatencyMs   float64
+}
+
+// BotMetrics implements services.MetricsSink, aggregating the counters
+// RunScraper reports into one sample per second and keeping the last
+// metricsRingSize of them for the GUI's sparklines.
+type BotMetrics struct {
+	gui *GUI
+
+	attempts, successes, errors int64 // atomically incremented from RunScraper's goroutine
+
+	mu         sync.Mutex
+	latencySum time.Duration
+	latencyN   int64
+	samples    []metricsSample // ring buffer, oldest first
+	failing    bool            // latest sample saw an error; colors the spark dangerColor
+
+	stop chan struct{}
+}
+
+var _ services.MetricsSink = (*BotMetrics)(nil)
+
+// newBotMetrics starts the per-second sampling goroutine; call Stop when
+// the bot run ends so it doesn't leak.
+func newBotMetrics(gui *GUI) *BotMetrics {
+	m := &BotMetrics{gui: gui, stop: make(chan struct{})}
+	go m.run()
+	return m
+}
+
+func (m *BotMetrics) RecordAttempt() { atomic.AddInt64(&m.attempts, 1) }
+func (m *BotMetrics) RecordSuccess() { atomic.AddInt64(&m.successes, 1) }
+func (m *BotMetrics) RecordError()   { atomic.AddInt64(&m.errors, 1) }
+
+func (m *BotMetrics) RecordLatency(d time.Duration) {
+	m.mu.Lock()
+	m.latencySum += d
+	m.latencyN++
+	m.mu.Unlock()
+}
+
+// Stop ends the sampling goroutine. Safe to call once.
+func (m *BotMetrics) Stop() { close(m.stop) }
+
+func (m *BotMetrics) run() {
+	ticker := time.NewTicker(metricsSampleInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			m.sample()
+		case <-m.stop:
+			return
+		}
+	}
+}
+
+func (m *BotMetrics) sample() {
+	attempts := atomic.SwapInt64(&m.attempts, 0)
+	successes := atomic.SwapInt64(&m.successes, 0)
+	errs := atomic.SwapInt64(&m.errors, 0)
+
+	s := metricsSample{
+		AttemptsPerSec: float64(attempts) / metricsSampleInterval.Seconds(),
+		Success:        int(successes),
+		Errors:         int(errs),
+	}
+
+	m.mu.Lock()
+	if m.latencyN > 0 {
+		s.AvgLatencyMs = float64(m.latencySum.Milliseconds()) / float64(m.latencyN)
+	}
+	m.latencySum, m.latencyN = 0, 0
+
+	m.samples = append(m.samples, s)
+	if len(m.samples) > metricsRingSize {
+		m.samples = m.samples[len(m.samples)-metricsRingSize:]
+	}
+	m.failing = errs > 0
+	m.mu.Unlock()
+
+	if m.gui != nil && m.gui.w != nil {
+		m.gui.w.Invalidate()
+	}
+}
+
+func (m *BotMetrics) snapshot() ([]metricsSample, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	out := make([]metricsSample, len(m.samples))
+	copy(out, m.samples)
+	return out, m.failing
+}
+
+// metricKind indexes the four series a BotMetrics tracks.
+type metricKind int
+
+const (
+	metricAttempts metricKind = iota
+	metricSuccess
+	metricErrors
+	metricLatency
+	metricKindCount
+)
+
+var metricSpecs = [metricKindCount]struct {
+	label string
+	unit  string
+	color color.NRGBA
+}{
+	metricAttempts: {"Attempts/s", "", accentColor},
+	metricSuccess:  {"Success", "", successColor},
+	metricErrors:   {"Errors", "", dangerColor},
+	metricLatency:  {"Avg Latency", "ms", purpleAccent},
+}
+
+func (k metricKind) values(samples []metricsSample) []float64 {
+	out := make([]float64, len(samples))
+	for i, s := range samples {
+		switch k {
+		case metricAttempts:
+			out[i] = s.AttemptsPerSec
+		case metricSuccess:
+			out[i] = float64(s.Success)
+		case metricErrors:
+			out[i] = float64(s.Errors)
+		case metricLatency:
+			out[i] = s.AvgLatencyMs
+		}
+	}
+	return out
+}
+
+// metricsCard is the per-series interactive state: click to expand a
+// sparkline into a full-width panel, hover over the expanded panel to read
+// a value off it.
+type metricsCard struct {
+	expand   widget.Clickable
+	hovering bool
+	hoverX   float32
+}
+
+// metricsPanel lays out all four series for one bot; at most one series is
+// expanded at a time.
+type metricsPanel struct {
+	cards    [metricKindCount]metricsCard
+	expanded int // -1 if none expanded
+}
+
+func newMetricsPanel() *metricsPanel {
+	return &metricsPanel{expanded: -1}
+}
+
+// layoutMetrics draws the "📈 METRICS" card, a sibling of the logs card in
+// the bot detail view.
+func (g *GUI) layoutMetrics(gtx C, bot *Bot) D {
+	horizontalPadding := unit.Dp(20)
+	if g.isMobile {
+		horizontalPadding = unit.Dp(16)
+	}
+
+	return layout.Inset{Left: horizontalPadding, Right: horizontalPadding, Bottom: unit.Dp(16)}.Layout(gtx, func(gtx C) D {
+		return widget.Border{
+			Color:        borderColor,
+			Width:        unit.Dp(1),
+			CornerRadius: unit.Dp(10),
+		}.Layout(gtx, func(gtx C) D {
+			defer clip.UniformRRect(image.Rectangle{Max: gtx.Constraints.Max}, gtx.Dp(10)).Push(gtx.Ops).Pop()
+			paint.Fill(gtx.Ops, cardBg)
+
+			innerPadding := unit.Dp(16)
+			if g.isMobile {
+				innerPadding = unit.Dp(12)
+			}
+
+			return layout.UniformInset(innerPadding).Layout(gtx, func(gtx C) D {
+				return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+					layout.Rigid(func(gtx C) D {
+						label := material.Body2(g.th, "📈 METRICS")
+						label.Color = accentColor
+						if g.isMobile {
+							label.TextSize = unit.Sp(13)
+						}
+						return layout.Inset{Bottom: unit.Dp(10)}.Layout(gtx, label.Layout)
+					}),
+					layout.Rigid(func(gtx C) D {
+						return g.layoutMetricsBody(gtx, bot)
+					}),
+				)
+			})
+		})
+	})
+}
+
+func (g *GUI) layoutMetricsBody(gtx C, bot *Bot) D {
+	if bot.metrics == nil {
+		label := material.Body2(g.th, "Start the bot to see live metrics")
+		label.Color = disabledColor
+		return label.Layout(gtx)
+	}
+
+	samples, failing := bot.metrics.snapshot()
+	lineColor := runningColor
+	if !bot.config.IsRunning {
+		lineColor = disabledColor
+	} else if failing {
+		lineColor = dangerColor
+	}
+
+	panel := bot.metricsPanel
+	for i := range panel.cards {
+		if panel.cards[i].expand.Clicked(gtx) {
+			if panel.expanded == i {
+				panel.expanded = -1
+			} else {
+				panel.expanded = i
+			}
+		}
+	}
+
+	if panel.expanded >= 0 {
+		k := metricKind(panel.expanded)
+		return g.layoutExpandedMetric(gtx, &panel.cards[k], k, samples, lineColor)
+	}
+
+	children := make([]layout.FlexChild, metricKindCount)
+	for i := 0; i < int(metricKindCount); i++ {
+		k := metricKind(i)
+		children[i] = layout.Flexed(1, func(gtx C) D {
+			return layout.Inset{Right: unit.Dp(8)}.Layout(gtx, func(gtx C) D {
+				return g.layoutSparkCard(gtx, &panel.cards[k], k, samples, lineColor)
+			})
+		})
+	}
+	return layout.Flex{Axis: layout.Horizontal}.Layout(gtx, children...)
+}
+
+func (g *GUI) layoutSparkCard(gtx C, card *metricsCard, k metricKind, samples []metricsSample, lineColor color.NRGBA) D {
+	spec := metricSpecs[k]
+
+	return card.expand.Layout(gtx, func(gtx C) D {
+		return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+			layout.Rigid(func(gtx C) D {
+				l := material.Caption(g.th, spec.label)
+				l.Color = purpleAccent
+				return l.Layout(gtx)
+			}),
+			layout.Rigid(func(gtx C) D {
+				gtx.Constraints.Min.Y, gtx.Constraints.Max.Y = gtx.Dp(40), gtx.Dp(40)
+				return drawSparkline(gtx, k.values(samples), lineColor)
+			}),
+			layout.Rigid(func(gtx C) D {
+				l := material.Caption(g.th, latestLabel(samples, k, spec.unit))
+				l.Color = textColor
+				return l.Layout(gtx)
+			}),
+		)
+	})
+}
+
+func (g *GUI) layoutExpandedMetric(gtx C, card *metricsCard, k metricKind, samples []metricsSample, lineColor color.NRGBA) D {
+	spec := metricSpecs[k]
+	values := k.values(samples)
+	minV, maxV := rangeOf(values)
+
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+		layout.Rigid(func(gtx C) D {
+			return card.expand.Layout(gtx, func(gtx C) D {
+				l := material.Body2(g.th, fmt.Sprintf("%s (click to collapse)", spec.label))
+				l.Color = accentColor
+				return l.Layout(gtx)
+			})
+		}),
+		layout.Rigid(layout.Spacer{Height: unit.Dp(8)}.Layout),
+		layout.Rigid(func(gtx C) D {
+			gtx.Constraints.Min.Y, gtx.Constraints.Max.Y = gtx.Dp(160), gtx.Dp(160)
+			return g.layoutHoverableSparkline(gtx, card, values, lineColor)
+		}),
+		layout.Rigid(layout.Spacer{Height: unit.Dp(8)}.Layout),
+		layout.Rigid(func(gtx C) D {
+			text := fmt.Sprintf("min %.1f%s · max %.1f%s · %s", minV, spec.unit, maxV, spec.unit, latestLabel(samples, k, spec.unit))
+			if card.hovering {
+				if idx := hoverIndex(gtx, card.hoverX, len(values)); idx >= 0 {
+					text = fmt.Sprintf("sample %d: %.1f%s", idx+1, values[idx], spec.unit)
+				}
+			}
+			l := material.Caption(g.th, text)
+			l.Color = textColor
+			return l.Layout(gtx)
+		}),
+	)
+}
+
+func latestLabel(samples []metricsSample, k metricKind, unit string) string {
+	if len(samples) == 0 {
+		return "–"
+	}
+	values := k.values(samples)
+	return fmt.Sprintf("%.1f%s", values[len(values)-1], unit)
+}
+
+func rangeOf(values []float64) (lo, hi float64) {
+	if len(values) == 0 {
+		return 0, 0
+	}
+	lo, hi = values[0], values[0]
+	for _, v := range values {
+		if v < lo {
+			lo = v
+		}
+		if v > hi {
+			hi = v
+		}
+	}
+	return lo, hi
+}
+
+// drawSparkline draws values as a compact polyline filling gtx's
+// constraints, with no axes or interaction.
+func drawSparkline(gtx C, values []float64, lineColor color.NRGBA) D {
+	size := gtx.Constraints.Max
+	if len(values) >= 2 {
+		path := buildPolyline(gtx.Ops, values, size)
+		paint.FillShape(gtx.Ops, lineColor, clip.Stroke{Path: path, Width: 2}.Op())
+	}
+	return D{Size: size}
+}
+
+// layoutHoverableSparkline draws the polyline plus a pointer area that
+// tracks the cursor, so the caller can report the value of the nearest
+// sample as a tooltip.
+func (g *GUI) layoutHoverableSparkline(gtx C, card *metricsCard, values []float64, lineColor color.NRGBA) D {
+	size := gtx.Constraints.Max
+
+	area := clip.Rect{Max: size}.Push(gtx.Ops)
+	event.Op(gtx.Ops, card)
+	area.Pop()
+
+	for {
+		ev, ok := gtx.Event(pointer.Filter{Target: card, Kinds: pointer.Move | pointer.Enter | pointer.Leave | pointer.Press})
+		if !ok {
+			break
+		}
+		pe, ok := ev.(pointer.Event)
+		if !ok {
+			continue
+		}
+		if pe.Kind == pointer.Leave {
+			card.hovering = false
+			continue
+		}
+		card.hovering = true
+		card.hoverX = pe.Position.X
+	}
+
+	if len(values) >= 2 {
+		path := buildPolyline(gtx.Ops, values, size)
+		paint.FillShape(gtx.Ops, lineColor, clip.Stroke{Path: path, Width: 2}.Op())
+	}
+
+	if card.hovering {
+		if idx := hoverIndex(gtx, card.hoverX, len(values)); idx >= 0 {
+			x := float32(idx) / float32(len(values)-1) * float32(size.X)
+			var marker clip.Path
+			marker.Begin(gtx.Ops)
+			marker.MoveTo(f32.Pt(x, 0))
+			marker.LineTo(f32.Pt(x, float32(size.Y)))
+			paint.FillShape(gtx.Ops, disabledColor, clip.Stroke{Path: marker.End(), Width: 1}.Op())
+		}
+	}
+
+	return D{Size: size}
+}
+
+func hoverIndex(gtx C, x float32, n int) int {
+	if n < 2 {
+		return -1
+	}
+	w := float32(gtx.Constraints.Max.X)
+	if w <= 0 {
+		return -1
+	}
+	idx := int(x / w * float32(n-1))
+	if idx < 0 {
+		idx = 0
+	}
+	if idx >= n {
+		idx = n - 1
+	}
+	return idx
+}
+
+// buildPolyline maps values (scaled to their own min/max range) onto size,
+// oldest-to-newest left-to-right.
+func buildPolyline(ops *op.Ops, values []float64, size image.Point) clip.PathSpec {
+	minV, maxV := rangeOf(values)
+	spread := maxV - minV
+	if spread == 0 {
+		spread = 1
+	}
+
+	var path clip.Path
+	path.Begin(ops)
+	for i, v := range values {
+		x := float32(i) / float32(len(values)-1) * float32(size.X)
+		y := float32(size.Y) - float32((v-minV)/spread)*float32(size.Y)
+		pt := f32.Pt(x, y)
+		if i == 0 {
+			path.MoveTo(pt)
+		} else {
+			path.LineTo(pt)
+		}
+	}
+	return path.End()
+}
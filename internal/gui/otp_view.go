@@ -0,0 +1,424 @@
+package gui
+
+import (
+	"fmt"
+	"image"
+	"math"
+	"strings"
+	"time"
+
+	"gioui.org/f32"
+	"gioui.org/layout"
+	"gioui.org/op/clip"
+	"gioui.org/op/paint"
+	"gioui.org/unit"
+	"gioui.org/widget"
+	"gioui.org/widget/material"
+
+	"tix-scraper/internal/auth/totp"
+)
+
+// OTPView is the "2FA" tab: an enrollment form for otpauth:// URIs or manual
+// fields, a list of enrolled sites, and a live countdown ring for whichever
+// site is selected. It mirrors UsersView's shape (a *GUI back-reference, one
+// Layout method, form state alongside per-row widgets).
+type OTPView struct {
+	gui *GUI
+
+	sites    []string
+	selected int
+
+	uriEditor     TextField
+	siteEditor    TextField
+	issuerEditor  TextField
+	accountEditor TextField
+	secretEditor  TextField
+
+	enrollURIBtn    widget.Clickable
+	enrollManualBtn widget.Clickable
+	refreshBtn      widget.Clickable
+	removeButtons   []widget.Clickable
+	selectButtons   []widget.Clickable
+
+	errMsg string
+
+	code       string
+	remaining  time.Duration
+	period     time.Duration
+	codeErr    string
+	lastTicked time.Time
+}
+
+// refreshSites re-reads the enrolled site list from the vault. The vault
+// must already be unlocked.
+func (ov *OTPView) refreshSites() {
+	sites, err := ov.gui.totp.Sites()
+	if err != nil {
+		ov.errMsg = err.Error()
+		return
+	}
+	ov.sites = sites
+	ov.removeButtons = make([]widget.Clickable, len(sites))
+	ov.selectButtons = make([]widget.Clickable, len(sites))
+	if ov.selected >= len(sites) {
+		ov.selected = -1
+	}
+}
+
+// refreshCode regenerates the displayed code for the selected site, at most
+// once a second, so Layout can call it unconditionally without hammering
+// the vault on every frame.
+func (ov *OTPView) refreshCode() {
+	if ov.selected < 0 || ov.selected >= len(ov.sites) {
+		ov.code = ""
+		return
+	}
+	now := time.Now()
+	if now.Sub(ov.lastTicked) < time.Second && ov.code != "" {
+		return
+	}
+	ov.lastTicked = now
+
+	code, remaining, err := ov.gui.totp.Code(ov.sites[ov.selected], now)
+	if err != nil {
+		ov.codeErr = err.Error()
+		ov.code = ""
+		return
+	}
+	ov.codeErr = ""
+	ov.code = code
+	ov.remaining = remaining
+	// period isn't returned directly; approximate it as the largest
+	// remaining duration seen since selection, which is close enough for
+	// the ring's fraction.
+	if ov.period == 0 || remaining > ov.period {
+		ov.period = remaining
+	}
+}
+
+func (ov *OTPView) Layout(gtx C) D {
+	if ov.refreshBtn.Clicked(gtx) {
+		ov.refreshSites()
+	}
+
+	if ov.sites == nil {
+		ov.refreshSites()
+	}
+
+	for i := range ov.selectButtons {
+		if ov.selectButtons[i].Clicked(gtx) {
+			ov.selected = i
+			ov.period = 0
+			ov.lastTicked = time.Time{}
+		}
+	}
+
+	var removedIndex = -1
+	for i := range ov.removeButtons {
+		if ov.removeButtons[i].Clicked(gtx) {
+			removedIndex = i
+			break
+		}
+	}
+	if removedIndex >= 0 && removedIndex < len(ov.sites) {
+		site := ov.sites[removedIndex]
+		if err := ov.gui.totp.Remove(site); err != nil {
+			ov.errMsg = err.Error()
+		} else {
+			ov.gui.logView.Write([]byte(fmt.Sprintf("🔐 Removed 2FA secret for %q\n", site)))
+			ov.refreshSites()
+		}
+	}
+
+	if ov.enrollURIBtn.Clicked(gtx) {
+		ov.enrollFromURI()
+	}
+	if ov.enrollManualBtn.Clicked(gtx) {
+		ov.enrollManual()
+	}
+
+	ov.refreshCode()
+	// The ring needs to keep redrawing as time passes even with no input.
+	ov.gui.invalidate()
+
+	list := &widget.List{List: layout.List{Axis: layout.Vertical}}
+	return material.List(ov.gui.th, list).Layout(gtx, 1, func(gtx C, i int) D {
+		return layout.UniformInset(unit.Dp(20)).Layout(gtx, func(gtx C) D {
+			return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+				layout.Rigid(func(gtx C) D {
+					return layout.Flex{Axis: layout.Horizontal, Alignment: layout.Middle}.Layout(gtx,
+						layout.Flexed(1, func(gtx C) D {
+							label := material.H5(ov.gui.th, fmt.Sprintf("🔐 2FA Secrets (%d)", len(ov.sites)))
+							label.Color = accentColor
+							return label.Layout(gtx)
+						}),
+						layout.Rigid(func(gtx C) D {
+							btn := material.Button(ov.gui.th, &ov.refreshBtn, "🔄 Refresh")
+							btn.Background = accentColor
+							btn.Color = bgColor
+							btn.CornerRadius = unit.Dp(8)
+							return btn.Layout(gtx)
+						}),
+					)
+				}),
+				layout.Rigid(layout.Spacer{Height: unit.Dp(16)}.Layout),
+				layout.Rigid(func(gtx C) D { return ov.layoutEnrollForm(gtx) }),
+				layout.Rigid(layout.Spacer{Height: unit.Dp(16)}.Layout),
+				layout.Rigid(func(gtx C) D { return ov.layoutCodeCard(gtx) }),
+				layout.Rigid(layout.Spacer{Height: unit.Dp(16)}.Layout),
+				layout.Rigid(func(gtx C) D { return ov.layoutSiteList(gtx) }),
+			)
+		})
+	})
+}
+
+func (ov *OTPView) layoutEnrollForm(gtx C) D {
+	return widget.Border{Color: borderColor, Width: unit.Dp(1), CornerRadius: unit.Dp(10)}.Layout(gtx, func(gtx C) D {
+		defer clip.UniformRRect(image.Rectangle{Max: gtx.Constraints.Max}, gtx.Dp(10)).Push(gtx.Ops).Pop()
+		paint.Fill(gtx.Ops, cardBg)
+
+		return layout.UniformInset(unit.Dp(20)).Layout(gtx, func(gtx C) D {
+			children := []layout.FlexChild{
+				layout.Rigid(func(gtx C) D {
+					label := material.Body1(ov.gui.th, "Enroll via otpauth:// URI (scanned from a QR code)")
+					label.Color = purpleAccent
+					return label.Layout(gtx)
+				}),
+				layout.Rigid(func(gtx C) D { return ov.gui.layoutFormRow(gtx, "🔑 Site name", &ov.siteEditor) }),
+				layout.Rigid(func(gtx C) D { return ov.gui.layoutFormRow(gtx, "🔗 otpauth:// URI", &ov.uriEditor) }),
+				layout.Rigid(func(gtx C) D {
+					btn := material.Button(ov.gui.th, &ov.enrollURIBtn, "➕ Enroll from URI")
+					btn.Background = successColor
+					btn.Color = bgColor
+					btn.CornerRadius = unit.Dp(8)
+					return btn.Layout(gtx)
+				}),
+				layout.Rigid(layout.Spacer{Height: unit.Dp(16)}.Layout),
+				layout.Rigid(func(gtx C) D {
+					label := material.Body1(ov.gui.th, "...or enter the secret manually")
+					label.Color = purpleAccent
+					return label.Layout(gtx)
+				}),
+				layout.Rigid(func(gtx C) D { return ov.gui.layoutFormRow(gtx, "🏷️ Issuer", &ov.issuerEditor) }),
+				layout.Rigid(func(gtx C) D { return ov.gui.layoutFormRow(gtx, "👤 Account", &ov.accountEditor) }),
+				layout.Rigid(func(gtx C) D { return ov.gui.layoutFormRow(gtx, "🔒 Base32 secret", &ov.secretEditor) }),
+				layout.Rigid(func(gtx C) D {
+					btn := material.Button(ov.gui.th, &ov.enrollManualBtn, "➕ Enroll manually")
+					btn.Background = successColor
+					btn.Color = bgColor
+					btn.CornerRadius = unit.Dp(8)
+					return btn.Layout(gtx)
+				}),
+			}
+
+			if ov.errMsg != "" {
+				children = append(children,
+					layout.Rigid(layout.Spacer{Height: unit.Dp(8)}.Layout),
+					layout.Rigid(func(gtx C) D {
+						label := material.Body2(ov.gui.th, ov.errMsg)
+						label.Color = dangerColor
+						return label.Layout(gtx)
+					}),
+				)
+			}
+
+			return layout.Flex{Axis: layout.Vertical}.Layout(gtx, children...)
+		})
+	})
+}
+
+func (ov *OTPView) enrollFromURI() {
+	site := strings.TrimSpace(ov.siteEditor.Value())
+	uri := strings.TrimSpace(ov.uriEditor.Value())
+	if site == "" || uri == "" {
+		ov.errMsg = "Site name and otpauth:// URI are both required"
+		return
+	}
+
+	secret, err := totp.ParseOTPAuthURI(site, uri)
+	if err != nil {
+		ov.errMsg = err.Error()
+		return
+	}
+	if err := ov.gui.totp.Enroll(secret); err != nil {
+		ov.errMsg = err.Error()
+		return
+	}
+
+	ov.errMsg = ""
+	ov.siteEditor.SetValue("")
+	ov.uriEditor.SetValue("")
+	ov.gui.logView.Write([]byte(fmt.Sprintf("🔐 Enrolled 2FA secret for %q\n", site)))
+	ov.refreshSites()
+}
+
+func (ov *OTPView) enrollManual() {
+	site := strings.TrimSpace(ov.siteEditor.Value())
+	secretB32 := strings.TrimSpace(ov.secretEditor.Value())
+	if site == "" || secretB32 == "" {
+		ov.errMsg = "Site name and base32 secret are both required"
+		return
+	}
+
+	secret := totp.Secret{
+		Site:      site,
+		Issuer:    ov.issuerEditor.Value(),
+		Account:   ov.accountEditor.Value(),
+		SecretB32: secretB32,
+		Digits:    totp.DefaultDigits,
+		Period:    int(totp.DefaultPeriod.Seconds()),
+		Algorithm: totp.AlgorithmSHA1,
+	}
+	if err := ov.gui.totp.Enroll(secret); err != nil {
+		ov.errMsg = err.Error()
+		return
+	}
+
+	ov.errMsg = ""
+	ov.siteEditor.SetValue("")
+	ov.issuerEditor.SetValue("")
+	ov.accountEditor.SetValue("")
+	ov.secretEditor.SetValue("")
+	ov.gui.logView.Write([]byte(fmt.Sprintf("🔐 Enrolled 2FA secret for %q\n", site)))
+	ov.refreshSites()
+}
+
+func (ov *OTPView) layoutCodeCard(gtx C) D {
+	return widget.Border{Color: borderColor, Width: unit.Dp(1), CornerRadius: unit.Dp(10)}.Layout(gtx, func(gtx C) D {
+		defer clip.UniformRRect(image.Rectangle{Max: gtx.Constraints.Max}, gtx.Dp(10)).Push(gtx.Ops).Pop()
+		paint.Fill(gtx.Ops, cardBg)
+
+		return layout.UniformInset(unit.Dp(20)).Layout(gtx, func(gtx C) D {
+			if ov.selected < 0 || ov.selected >= len(ov.sites) {
+				label := material.Body1(ov.gui.th, "Select an enrolled site below to watch its live code")
+				label.Color = disabledColor
+				return label.Layout(gtx)
+			}
+			if ov.codeErr != "" {
+				label := material.Body1(ov.gui.th, ov.codeErr)
+				label.Color = dangerColor
+				return label.Layout(gtx)
+			}
+
+			fraction := 0.0
+			if ov.period > 0 {
+				fraction = float64(ov.remaining) / float64(ov.period)
+			}
+
+			return layout.Flex{Axis: layout.Horizontal, Alignment: layout.Middle}.Layout(gtx,
+				layout.Rigid(func(gtx C) D { return layoutCountdownRing(gtx, fraction) }),
+				layout.Rigid(layout.Spacer{Width: unit.Dp(20)}.Layout),
+				layout.Rigid(func(gtx C) D {
+					return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+						layout.Rigid(func(gtx C) D {
+							label := material.H4(ov.gui.th, ov.code)
+							label.Color = accentColor
+							return label.Layout(gtx)
+						}),
+						layout.Rigid(func(gtx C) D {
+							label := material.Caption(ov.gui.th, fmt.Sprintf("%s — rotates in %ds", ov.sites[ov.selected], int(ov.remaining.Round(time.Second).Seconds())))
+							label.Color = disabledColor
+							return label.Layout(gtx)
+						}),
+					)
+				}),
+			)
+		})
+	})
+}
+
+// layoutCountdownRing draws a circular progress ring that drains clockwise
+// from 12 o'clock as fraction falls from 1 to 0, so the operator can see at
+// a glance how long the displayed code has left before it rotates.
+func layoutCountdownRing(gtx C, fraction float64) D {
+	const diameter = unit.Dp(48)
+	size := gtx.Dp(diameter)
+	gtx.Constraints.Min = image.Pt(size, size)
+	gtx.Constraints.Max = gtx.Constraints.Min
+
+	center := float32(size) / 2
+	radius := center - 4
+
+	track := clip.Ellipse{Min: image.Pt(0, 0), Max: image.Pt(size, size)}
+	paint.FillShape(gtx.Ops, borderColor, clip.Stroke{Path: track.Path(gtx.Ops), Width: 4}.Op())
+
+	if fraction > 0 {
+		ringColor := successColor
+		if fraction < 0.2 {
+			ringColor = dangerColor
+		} else if fraction < 0.5 {
+			ringColor = runningColor
+		}
+
+		var path clip.Path
+		path.Begin(gtx.Ops)
+		const steps = 64
+		arc := fraction * 2 * math.Pi
+		for i := 0; i <= steps; i++ {
+			t := arc * float64(i) / steps
+			// Start at 12 o'clock (-90deg) and sweep clockwise.
+			x := center + radius*float32(math.Sin(t))
+			y := center - radius*float32(math.Cos(t))
+			if i == 0 {
+				path.MoveTo(f32.Pt(x, y))
+			} else {
+				path.LineTo(f32.Pt(x, y))
+			}
+		}
+		paint.FillShape(gtx.Ops, ringColor, clip.Stroke{Path: path.End(), Width: 4}.Op())
+	}
+
+	return D{Size: image.Pt(size, size)}
+}
+
+func (ov *OTPView) layoutSiteList(gtx C) D {
+	if len(ov.sites) == 0 {
+		label := material.Body1(ov.gui.th, "No 2FA secrets enrolled yet")
+		label.Color = disabledColor
+		return label.Layout(gtx)
+	}
+
+	list := &widget.List{List: layout.List{Axis: layout.Vertical}}
+	return material.List(ov.gui.th, list).Layout(gtx, len(ov.sites), func(gtx C, i int) D {
+		return layout.Inset{Bottom: unit.Dp(8)}.Layout(gtx, func(gtx C) D {
+			return ov.layoutSiteRow(gtx, i)
+		})
+	})
+}
+
+func (ov *OTPView) layoutSiteRow(gtx C, index int) D {
+	site := ov.sites[index]
+	borderCol := borderColor
+	if index == ov.selected {
+		borderCol = accentColor
+	}
+
+	return widget.Border{Color: borderCol, Width: unit.Dp(1), CornerRadius: unit.Dp(8)}.Layout(gtx, func(gtx C) D {
+		defer clip.UniformRRect(image.Rectangle{Max: gtx.Constraints.Max}, gtx.Dp(8)).Push(gtx.Ops).Pop()
+		paint.Fill(gtx.Ops, cardBg)
+
+		return ov.selectButtons[index].Layout(gtx, func(gtx C) D {
+			return layout.UniformInset(unit.Dp(12)).Layout(gtx, func(gtx C) D {
+				return layout.Flex{Axis: layout.Horizontal, Alignment: layout.Middle}.Layout(gtx,
+					layout.Flexed(1, func(gtx C) D {
+						label := material.Body2(ov.gui.th, site)
+						label.Color = textColor
+						return label.Layout(gtx)
+					}),
+					layout.Rigid(func(gtx C) D {
+						return widget.Border{Color: dangerColor, Width: unit.Dp(1), CornerRadius: unit.Dp(4)}.Layout(gtx, func(gtx C) D {
+							defer clip.UniformRRect(image.Rectangle{Max: gtx.Constraints.Max}, gtx.Dp(4)).Push(gtx.Ops).Pop()
+							return ov.removeButtons[index].Layout(gtx, func(gtx C) D {
+								return layout.UniformInset(unit.Dp(4)).Layout(gtx, func(gtx C) D {
+									label := material.Caption(ov.gui.th, "✕")
+									label.Color = dangerColor
+									return label.Layout(gtx)
+								})
+							})
+						})
+					}),
+				)
+			})
+		})
+	})
+}
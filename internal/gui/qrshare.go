@@ -0,0 +1,207 @@
+package gui
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/color"
+	"strings"
+	"time"
+
+	"github.com/skip2/go-qrcode"
+
+	"gioui.org/layout"
+	"gioui.org/op/clip"
+	"gioui.org/op/paint"
+	"gioui.org/unit"
+	"gioui.org/widget"
+	"gioui.org/widget/material"
+)
+
+// qrPayloadVersion tags the JSON shared via QR so a future format change
+// can still recognize (and reject, or migrate) older payloads.
+const qrPayloadVersion = 1
+
+// shareBotConfigPayload is the JSON a bot-config QR code encodes, URL-safe
+// base64'd so it survives being typed or pasted by hand on a second device.
+type shareBotConfigPayload struct {
+	Version int       `json:"v"`
+	Config  BotConfig `json:"config"`
+}
+
+// qrModal renders a generated QR code, or a paste-to-import text box, as a
+// blocking overlay drawn on top of whatever the caller already laid out.
+type qrModal struct {
+	visible bool
+	title   string
+	imageOp paint.ImageOp
+
+	importMode bool
+	importEd   TextField
+	importBtn  widget.Clickable
+	onImport   func(payload string) error
+	errMsg     string
+
+	closeBtn widget.Clickable
+}
+
+// showQR generates a QR code encoding data and opens the modal to display
+// it.
+func (g *GUI) showQR(title, data string) {
+	qr, err := qrcode.New(data, qrcode.Medium)
+	if err != nil {
+		g.logView.Write([]byte(fmt.Sprintf("❌ Error generating QR code: %v\n", err)))
+		return
+	}
+
+	g.qr = qrModal{
+		visible: true,
+		title:   title,
+		imageOp: paint.NewImageOp(qr.Image(256)),
+	}
+	g.w.Invalidate()
+}
+
+// showImportQR opens the modal in paste-to-import mode; onImport is called
+// with the trimmed pasted payload when the user confirms.
+func (g *GUI) showImportQR(title string, onImport func(payload string) error) {
+	g.qr = qrModal{
+		visible:    true,
+		title:      title,
+		importMode: true,
+		importEd:   TextField{Editor: widget.Editor{SingleLine: true}},
+		onImport:   onImport,
+	}
+	g.w.Invalidate()
+}
+
+// shareUserSID encodes a user's raw SID cookie as a QR code, for moving a
+// logged-in session to a second device without retyping the 64-char value.
+func (g *GUI) shareUserSID(user User) {
+	g.showQR(fmt.Sprintf("Share SID: %s", user.Username), user.SID)
+}
+
+// shareBotConfig encodes cfg (minus the resolved SID, which the recipient
+// must supply from their own account) as a URL-safe base64 JSON QR code.
+func (g *GUI) shareBotConfig(cfg BotConfig) {
+	cfg.SID = ""
+	cfg.IsRunning = false
+
+	data, err := json.Marshal(shareBotConfigPayload{Version: qrPayloadVersion, Config: cfg})
+	if err != nil {
+		g.logView.Write([]byte(fmt.Sprintf("❌ Error encoding bot config: %v\n", err)))
+		return
+	}
+
+	g.showQR(fmt.Sprintf("Share bot: %s", cfg.Name), base64.URLEncoding.EncodeToString(data))
+}
+
+// importSIDPayload feeds a pasted SID straight into the Accounts tab's SID
+// editor, ready for the usual "validate and add" flow.
+func (g *GUI) importSIDPayload(payload string) error {
+	g.usersView.sidEditor.SetValue(strings.TrimSpace(payload))
+	return nil
+}
+
+// importBotConfigPayload decodes a QR payload produced by shareBotConfig
+// and appends a new Bot built from it.
+func (g *GUI) importBotConfigPayload(payload string) error {
+	data, err := base64.URLEncoding.DecodeString(strings.TrimSpace(payload))
+	if err != nil {
+		return fmt.Errorf("not a valid bot config payload: %w", err)
+	}
+
+	var shared shareBotConfigPayload
+	if err := json.Unmarshal(data, &shared); err != nil {
+		return fmt.Errorf("not a valid bot config payload: %w", err)
+	}
+
+	shared.Config.ID = fmt.Sprintf("bot_%d", time.Now().Unix())
+	shared.Config.IsRunning = false
+	bot := newBotFromConfig(shared.Config)
+
+	g.bots = append(g.bots, bot)
+	g.selectedBot = len(g.bots) - 1
+	g.saveBots()
+	g.logView.Write([]byte(fmt.Sprintf("📥 Imported bot: %s\n", bot.config.Name)))
+
+	return nil
+}
+
+// Layout draws m on top of the already-rendered main tree, dimming the
+// background behind it.
+func (m *qrModal) Layout(gtx C, g *GUI) D {
+	if m.closeBtn.Clicked(gtx) {
+		m.visible = false
+		return D{}
+	}
+
+	if m.importMode && m.importBtn.Clicked(gtx) {
+		if err := m.onImport(m.importEd.Value()); err != nil {
+			m.errMsg = err.Error()
+		} else {
+			m.visible = false
+		}
+		g.w.Invalidate()
+	}
+
+	paint.FillShape(gtx.Ops, color.NRGBA{A: 200}, clip.Rect{Max: gtx.Constraints.Max}.Op())
+
+	return layout.Center.Layout(gtx, func(gtx C) D {
+		border := widget.Border{Color: borderColor, CornerRadius: unit.Dp(12), Width: unit.Dp(1)}
+		return border.Layout(gtx, func(gtx C) D {
+			defer clip.UniformRRect(image.Rectangle{Max: gtx.Constraints.Max}, gtx.Dp(12)).Push(gtx.Ops).Pop()
+			paint.Fill(gtx.Ops, cardBg)
+
+			return layout.UniformInset(unit.Dp(24)).Layout(gtx, func(gtx C) D {
+				children := []layout.FlexChild{
+					layout.Rigid(func(gtx C) D {
+						label := material.H6(g.th, m.title)
+						label.Color = accentColor
+						return label.Layout(gtx)
+					}),
+					layout.Rigid(layout.Spacer{Height: unit.Dp(12)}.Layout),
+				}
+
+				if m.importMode {
+					children = append(children,
+						layout.Rigid(material.Editor(g.th, &m.importEd.Editor, "Paste QR payload").Layout),
+						layout.Rigid(layout.Spacer{Height: unit.Dp(8)}.Layout),
+					)
+					if m.errMsg != "" {
+						children = append(children,
+							layout.Rigid(func(gtx C) D {
+								label := material.Body2(g.th, m.errMsg)
+								label.Color = dangerColor
+								return label.Layout(gtx)
+							}),
+							layout.Rigid(layout.Spacer{Height: unit.Dp(8)}.Layout),
+						)
+					}
+					children = append(children, layout.Rigid(func(gtx C) D {
+						btn := material.Button(g.th, &m.importBtn, "Import")
+						btn.Background = accentColor
+						btn.Color = bgColor
+						return btn.Layout(gtx)
+					}))
+				} else {
+					children = append(children, layout.Rigid(func(gtx C) D {
+						gtx.Constraints.Min = image.Pt(256, 256)
+						gtx.Constraints.Max = gtx.Constraints.Min
+						return widget.Image{Src: m.imageOp, Fit: widget.Contain}.Layout(gtx)
+					}))
+				}
+
+				children = append(children,
+					layout.Rigid(layout.Spacer{Height: unit.Dp(16)}.Layout),
+					layout.Rigid(func(gtx C) D {
+						return material.Button(g.th, &m.closeBtn, "Close").Layout(gtx)
+					}),
+				)
+
+				return layout.Flex{Axis: layout.Vertical}.Layout(gtx, children...)
+			})
+		})
+	})
+}
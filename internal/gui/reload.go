@@ -0,0 +1,205 @@
+package gui
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+
+	"tix-scraper/internal/paths"
+
+	"gioui.org/widget"
+)
+
+// reloadDebounce collapses a burst of fsnotify events for the same file
+// (common with editors that write-then-rename) into a single reload.
+const reloadDebounce = 250 * time.Millisecond
+
+// selfWriteGuard is how long watchDataDir ignores events after any of the
+// app's own writes to g.configDir, so saveBots/vault.Save don't trigger a reload
+// of the file they just wrote.
+const selfWriteGuard = 500 * time.Millisecond
+
+// watchDataDir watches g.configDir for edits made outside this process (a
+// hand edit, or another running instance) and reloads the affected
+// in-memory store. It is a no-op if the directory can't be watched;
+// hot-reload is a convenience, not a requirement to run.
+func (g *GUI) watchDataDir() {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		log.Printf("❌ Error watching config directory: %v\n", err)
+		return
+	}
+	if err := watcher.Add(g.configDir); err != nil {
+		log.Printf("❌ Error watching config directory: %v\n", err)
+		watcher.Close()
+		return
+	}
+
+	go func() {
+		defer watcher.Close()
+
+		var debounce <-chan time.Time
+		var pendingBots, pendingUsers bool
+
+		for {
+			select {
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if event.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Rename) == 0 {
+					continue
+				}
+				if g.withinSelfWriteGuard() {
+					continue
+				}
+
+				switch filepath.Base(event.Name) {
+				case paths.BotsConfigFile:
+					pendingBots = true
+				case paths.VaultFile:
+					pendingUsers = true
+				default:
+					continue
+				}
+				debounce = time.After(reloadDebounce)
+
+			case <-debounce:
+				debounce = nil
+				if pendingBots {
+					g.reloadBots()
+					pendingBots = false
+				}
+				if pendingUsers {
+					g.reloadUsers()
+					pendingUsers = false
+				}
+
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				log.Printf("❌ Data directory watcher error: %v\n", err)
+			}
+		}
+	}()
+}
+
+// markSelfWrite suppresses watchDataDir reloads for selfWriteGuard, for
+// callers that just wrote one of the watched files themselves.
+func (g *GUI) markSelfWrite() {
+	g.mu.Lock()
+	g.selfWriteUntil = time.Now().Add(selfWriteGuard)
+	g.mu.Unlock()
+}
+
+func (g *GUI) withinSelfWriteGuard() bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return time.Now().Before(g.selfWriteUntil)
+}
+
+// reloadBots re-reads bots_config.json and merges it into g.bots: bots that
+// still exist on disk keep their IsRunning state and cancel func (and their
+// widget state), bots no longer on disk are dropped, and newly appearing
+// ones are added.
+func (g *GUI) reloadBots() {
+	data, err := os.ReadFile(g.botsConfigPath())
+	if err != nil {
+		if !os.IsNotExist(err) {
+			g.logView.Write([]byte(fmt.Sprintf("❌ Error reloading bots: %v\n", err)))
+		}
+		return
+	}
+
+	var configs []BotConfig
+	if err := json.Unmarshal(data, &configs); err != nil {
+		g.logView.Write([]byte(fmt.Sprintf("❌ Error parsing reloaded bots config: %v\n", err)))
+		return
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	existing := make(map[string]*Bot, len(g.bots))
+	for _, bot := range g.bots {
+		existing[bot.config.ID] = bot
+	}
+
+	var selectedID string
+	if g.selectedBot >= 0 && g.selectedBot < len(g.bots) {
+		selectedID = g.bots[g.selectedBot].config.ID
+	}
+
+	merged := make([]*Bot, 0, len(configs))
+	for _, cfg := range configs {
+		bot, ok := existing[cfg.ID]
+		if !ok {
+			merged = append(merged, newBotFromConfig(cfg))
+			continue
+		}
+
+		running, cancel := bot.config.IsRunning, bot.cancel
+		bot.config = cfg
+		bot.config.IsRunning = running
+		bot.cancel = cancel
+		bot.nameEditor.SetValue(cfg.Name)
+		bot.eventIDEditor.SetValue(cfg.EventID)
+		bot.ticketIDEditor.SetValue(cfg.TicketID)
+		bot.filterEditor.SetValue(cfg.Filter)
+		bot.quantityEditor.SetValue(cfg.Quantity)
+		bot.maxTicketsEditor.SetValue(cfg.MaxTickets)
+		bot.preSaleEditor.SetValue(cfg.PreSaleCode)
+		bot.dateEditor.SetValue(cfg.StartDate)
+		bot.scheduleCheckbox.Value = cfg.Schedule
+		bot.loopCheckbox.Value = cfg.Loop
+		merged = append(merged, bot)
+	}
+	g.bots = merged
+
+	g.selectedBot = -1
+	for i, bot := range g.bots {
+		if bot.config.ID == selectedID {
+			g.selectedBot = i
+			break
+		}
+	}
+	if g.selectedBot == -1 && len(g.bots) > 0 {
+		g.selectedBot = 0
+	}
+
+	g.logView.Write([]byte("🔄 Reloaded bots_config.json after an external change\n"))
+	if g.w != nil {
+		g.w.Invalidate()
+	}
+}
+
+// reloadUsers re-reads the vault after an external change. It's skipped
+// while locked, since we have no key to decrypt with.
+func (g *GUI) reloadUsers() {
+	if g.vault.Locked() {
+		return
+	}
+
+	var users []User
+	if err := g.vault.Load(&users); err != nil {
+		g.logView.Write([]byte(fmt.Sprintf("❌ Error reloading vault: %v\n", err)))
+		return
+	}
+
+	g.usersView.mu.Lock()
+	g.usersView.users = users
+	g.usersView.deleteButtons = make([]widget.Clickable, len(users))
+	g.usersView.shareButtons = make([]widget.Clickable, len(users))
+	g.usersView.mu.Unlock()
+
+	g.logView.Write([]byte("🔄 Reloaded users from the vault after an external change\n"))
+	if g.w != nil {
+		g.w.Invalidate()
+	}
+}
@@ -0,0 +1,173 @@
+package gui
+
+import (
+	"context"
+	"fmt"
+	"image"
+	"sort"
+	"time"
+
+	"gioui.org/layout"
+	"gioui.org/op/clip"
+	"gioui.org/op/paint"
+	"gioui.org/unit"
+	"gioui.org/widget"
+	"gioui.org/widget/material"
+
+	"tix-scraper/internal/scheduler"
+)
+
+// JobsView is the "Jobs" tab: a read-only list of the scheduler's
+// registered jobs (ID, site, next fire, last result) with a "Run Now"
+// button per row. Jobs themselves are registered in code (there's no
+// add-job form here yet), mirroring how OTPView's site list only manages
+// entries already enrolled through the vault.
+type JobsView struct {
+	gui *GUI
+
+	statuses    []scheduler.JobStatus
+	runNowBtns  []widget.Clickable
+	refreshBtn  widget.Clickable
+	lastRefresh time.Time
+	statusMsg   string
+}
+
+func (jv *JobsView) refresh() {
+	if jv.gui.scheduler == nil {
+		jv.statuses = nil
+		return
+	}
+	statuses := jv.gui.scheduler.Status()
+	sort.Slice(statuses, func(i, k int) bool { return statuses[i].Job.ID < statuses[k].Job.ID })
+	jv.statuses = statuses
+	if len(jv.runNowBtns) != len(jv.statuses) {
+		jv.runNowBtns = make([]widget.Clickable, len(jv.statuses))
+	}
+	jv.lastRefresh = time.Now()
+}
+
+func (jv *JobsView) Layout(gtx C) D {
+	if jv.refreshBtn.Clicked(gtx) || time.Since(jv.lastRefresh) > time.Second {
+		jv.refresh()
+	}
+
+	for i := range jv.runNowBtns {
+		if jv.runNowBtns[i].Clicked(gtx) {
+			job := jv.statuses[i].Job
+			if jv.gui.scheduler != nil {
+				if err := jv.gui.scheduler.RunNow(context.Background(), job.ID); err != nil {
+					jv.statusMsg = err.Error()
+				} else {
+					jv.statusMsg = fmt.Sprintf("Triggered %s", job.ID)
+				}
+			}
+		}
+	}
+
+	jv.gui.invalidate()
+
+	list := &widget.List{List: layout.List{Axis: layout.Vertical}}
+	return material.List(jv.gui.th, list).Layout(gtx, 1, func(gtx C, _ int) D {
+		return layout.UniformInset(unit.Dp(20)).Layout(gtx, func(gtx C) D {
+			return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+				layout.Rigid(func(gtx C) D {
+					return layout.Flex{Axis: layout.Horizontal, Alignment: layout.Middle}.Layout(gtx,
+						layout.Flexed(1, func(gtx C) D {
+							label := material.H5(jv.gui.th, fmt.Sprintf("⏱ Scheduled Jobs (%d)", len(jv.statuses)))
+							label.Color = accentColor
+							return label.Layout(gtx)
+						}),
+						layout.Rigid(func(gtx C) D {
+							btn := material.Button(jv.gui.th, &jv.refreshBtn, "🔄 Refresh")
+							btn.Background = accentColor
+							btn.Color = bgColor
+							btn.CornerRadius = unit.Dp(8)
+							return btn.Layout(gtx)
+						}),
+					)
+				}),
+				layout.Rigid(layout.Spacer{Height: unit.Dp(16)}.Layout),
+				layout.Rigid(func(gtx C) D {
+					if jv.statusMsg == "" {
+						return D{}
+					}
+					label := material.Caption(jv.gui.th, jv.statusMsg)
+					label.Color = textColor
+					return label.Layout(gtx)
+				}),
+				layout.Rigid(layout.Spacer{Height: unit.Dp(8)}.Layout),
+				layout.Rigid(func(gtx C) D { return jv.layoutJobList(gtx) }),
+			)
+		})
+	})
+}
+
+func (jv *JobsView) layoutJobList(gtx C) D {
+	if len(jv.statuses) == 0 {
+		label := material.Body1(jv.gui.th, "No jobs registered")
+		label.Color = disabledColor
+		return label.Layout(gtx)
+	}
+
+	list := &widget.List{List: layout.List{Axis: layout.Vertical}}
+	return material.List(jv.gui.th, list).Layout(gtx, len(jv.statuses), func(gtx C, i int) D {
+		return layout.Inset{Bottom: unit.Dp(8)}.Layout(gtx, func(gtx C) D {
+			return jv.layoutJobRow(gtx, i)
+		})
+	})
+}
+
+func (jv *JobsView) layoutJobRow(gtx C, index int) D {
+	status := jv.statuses[index]
+
+	return widget.Border{Color: borderColor, Width: unit.Dp(1), CornerRadius: unit.Dp(8)}.Layout(gtx, func(gtx C) D {
+		defer clip.UniformRRect(image.Rectangle{Max: gtx.Constraints.Max}, gtx.Dp(8)).Push(gtx.Ops).Pop()
+		paint.Fill(gtx.Ops, cardBg)
+
+		return layout.UniformInset(unit.Dp(12)).Layout(gtx, func(gtx C) D {
+			return layout.Flex{Axis: layout.Horizontal, Alignment: layout.Middle}.Layout(gtx,
+				layout.Flexed(1, func(gtx C) D {
+					return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+						layout.Rigid(func(gtx C) D {
+							label := material.Body2(jv.gui.th, fmt.Sprintf("%s (%s)", status.Job.ID, status.Job.Site))
+							label.Color = textColor
+							return label.Layout(gtx)
+						}),
+						layout.Rigid(func(gtx C) D {
+							label := material.Caption(jv.gui.th, fmt.Sprintf("next: %s  |  last: %s", formatFireTime(status.NextFire), jv.lastResult(status)))
+							label.Color = disabledColor
+							return label.Layout(gtx)
+						}),
+					)
+				}),
+				layout.Rigid(func(gtx C) D {
+					btn := material.Button(jv.gui.th, &jv.runNowBtns[index], "▶ Run Now")
+					btn.Background = accentColor
+					btn.Color = bgColor
+					btn.CornerRadius = unit.Dp(8)
+					return btn.Layout(gtx)
+				}),
+			)
+		})
+	})
+}
+
+func (jv *JobsView) lastResult(status scheduler.JobStatus) string {
+	if status.Running {
+		return "running"
+	}
+	if status.LastFire.IsZero() {
+		return "never run"
+	}
+	if status.LastErr != nil {
+		return fmt.Sprintf("failed: %v", status.LastErr)
+	}
+	return "ok at " + formatFireTime(status.LastFire)
+}
+
+func formatFireTime(t time.Time) string {
+	if t.IsZero() {
+		return "n/a"
+	}
+	return t.Format("2006-01-02 15:04:05")
+}
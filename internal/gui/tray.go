@@ -0,0 +1,104 @@
+package gui
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/getlantern/systray"
+
+	"tix-scraper/internal/daemon"
+)
+
+// runTrayIcon blocks rendering the system tray icon and handling its menu
+// until the process exits. It reflects the daemon's aggregate bot status in
+// the tooltip and reacts to Start All / Stop All / Show Window / Quit.
+func (g *GUI) runTrayIcon() {
+	systray.Run(g.onTrayReady, g.onTrayExit)
+}
+
+func (g *GUI) onTrayReady() {
+	systray.SetTitle("Tix Scraper")
+	systray.SetTooltip(g.trayTooltip())
+
+	showItem := systray.AddMenuItem("Show Window", "Reopen the main window")
+	startAllItem := systray.AddMenuItem("Start All", "Start every configured bot")
+	stopAllItem := systray.AddMenuItem("Stop All", "Stop every running bot")
+	systray.AddSeparator()
+	quitItem := systray.AddMenuItem("Quit", "Stop all bots and exit")
+
+	go func() {
+		for range g.daemon.Updates() {
+			systray.SetTooltip(g.trayTooltip())
+		}
+	}()
+
+	for {
+		select {
+		case <-showItem.ClickedCh:
+			if g.w == nil {
+				g.openWindow()
+			}
+		case <-startAllItem.ClickedCh:
+			g.daemon.StartAll(g.daemonConfigs())
+		case <-stopAllItem.ClickedCh:
+			g.daemon.StopAll()
+		case <-quitItem.ClickedCh:
+			g.daemon.StopAll()
+			systray.Quit()
+			return
+		}
+	}
+}
+
+func (g *GUI) onTrayExit() {
+	os.Exit(0)
+}
+
+// trayTooltip summarizes the daemon's bots as "idle", "N running", or an
+// error count for the tray icon tooltip.
+func (g *GUI) trayTooltip() string {
+	statuses := g.daemon.Snapshot()
+
+	running, failed := 0, 0
+	for _, s := range statuses {
+		switch s.State {
+		case daemon.StateRunning:
+			running++
+		case daemon.StateFailed:
+			failed++
+		}
+	}
+
+	switch {
+	case failed > 0:
+		return fmt.Sprintf("Tix Scraper - error (%d failed)", failed)
+	case running > 0:
+		return fmt.Sprintf("Tix Scraper - %d running", running)
+	default:
+		return "Tix Scraper - idle"
+	}
+}
+
+// daemonConfigs converts every configured Bot into the daemon's BotConfig
+// shape for headless execution.
+func (g *GUI) daemonConfigs() []daemon.BotConfig {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	cfgs := make([]daemon.BotConfig, 0, len(g.bots))
+	for _, bot := range g.bots {
+		cfgs = append(cfgs, daemon.BotConfig{
+			ID:          bot.config.ID,
+			Name:        bot.config.Name,
+			SID:         bot.config.SID,
+			EventID:     bot.config.EventID,
+			TicketID:    bot.config.TicketID,
+			Filter:      bot.config.Filter,
+			Quantity:    bot.config.Quantity,
+			MaxTickets:  bot.config.MaxTickets,
+			PreSaleCode: bot.config.PreSaleCode,
+			Loop:        bot.config.Loop,
+		})
+	}
+	return cfgs
+}
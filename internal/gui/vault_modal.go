@@ -0,0 +1,162 @@
+package gui
+
+import (
+	"os"
+
+	"gioui.org/layout"
+	"gioui.org/op/paint"
+	"gioui.org/unit"
+	"gioui.org/widget"
+	"gioui.org/widget/material"
+)
+
+// passphraseModal is a blocking, pinentry-style overlay drawn in place of
+// the main tree until the vault is unlocked.
+type passphraseModal struct {
+	passEditor    TextField
+	confirmEditor TextField
+	submitBtn     widget.Clickable
+	errMsg        string
+}
+
+func newPassphraseModal() passphraseModal {
+	m := passphraseModal{
+		passEditor:    TextField{Editor: widget.Editor{SingleLine: true, Mask: '*'}},
+		confirmEditor: TextField{Editor: widget.Editor{SingleLine: true, Mask: '*'}},
+	}
+	return m
+}
+
+// layoutPassphraseModal draws the unlock/first-run prompt. It is rendered
+// instead of Layout's normal tree whenever the vault is locked.
+func (g *GUI) layoutPassphraseModal(gtx C) D {
+	m := &g.passModal
+	firstRun := !g.vault.Exists()
+
+	if m.submitBtn.Clicked(gtx) {
+		pass := m.passEditor.Value()
+		switch {
+		case pass == "":
+			m.errMsg = "Passphrase cannot be empty"
+		case firstRun && pass != m.confirmEditor.Value():
+			m.errMsg = "Passphrases do not match"
+		default:
+			g.unlockVault(pass, firstRun)
+		}
+	}
+
+	paint.Fill(gtx.Ops, bgColor)
+
+	return layout.Center.Layout(gtx, func(gtx C) D {
+		border := widget.Border{Color: borderColor, CornerRadius: unit.Dp(12), Width: unit.Dp(1)}
+		return border.Layout(gtx, func(gtx C) D {
+			return layout.UniformInset(unit.Dp(24)).Layout(gtx, func(gtx C) D {
+				title := "🔒 Unlock Tix Scraper"
+				if firstRun {
+					title = "🔒 Set a master passphrase"
+				}
+
+				children := []layout.FlexChild{
+					layout.Rigid(func(gtx C) D {
+						label := material.H6(g.th, title)
+						label.Color = accentColor
+						return label.Layout(gtx)
+					}),
+					layout.Rigid(layout.Spacer{Height: unit.Dp(12)}.Layout),
+					layout.Rigid(material.Editor(g.th, &m.passEditor.Editor, "Passphrase").Layout),
+				}
+
+				if firstRun {
+					children = append(children,
+						layout.Rigid(layout.Spacer{Height: unit.Dp(8)}.Layout),
+						layout.Rigid(material.Editor(g.th, &m.confirmEditor.Editor, "Confirm passphrase").Layout),
+					)
+				}
+
+				if m.errMsg != "" {
+					children = append(children,
+						layout.Rigid(layout.Spacer{Height: unit.Dp(8)}.Layout),
+						layout.Rigid(func(gtx C) D {
+							label := material.Body2(g.th, m.errMsg)
+							label.Color = dangerColor
+							return label.Layout(gtx)
+						}),
+					)
+				}
+
+				children = append(children,
+					layout.Rigid(layout.Spacer{Height: unit.Dp(16)}.Layout),
+					layout.Rigid(func(gtx C) D {
+						btn := material.Button(g.th, &m.submitBtn, "Unlock")
+						btn.Background = accentColor
+						btn.Color = bgColor
+						return btn.Layout(gtx)
+					}),
+				)
+
+				return layout.Flex{Axis: layout.Vertical}.Layout(gtx, children...)
+			})
+		})
+	})
+}
+
+// unlockVault derives the vault key from pass and loads (or, on first run,
+// migrates or creates) the encrypted user store, then reveals the main UI.
+func (g *GUI) unlockVault(pass string, firstRun bool) {
+	if err := g.vault.Unlock(pass); err != nil {
+		g.passModal.errMsg = err.Error()
+		return
+	}
+	// The TOTP vault shares the user's one master passphrase; its own
+	// salt still makes its derived key distinct from g.vault's.
+	if err := g.totp.Unlock(pass); err != nil {
+		g.passModal.errMsg = err.Error()
+		return
+	}
+
+	var users []User
+	if firstRun {
+		legacy, err := os.ReadFile(g.legacyUsersPath())
+		if err == nil {
+			if err := g.vault.MigrateFromPlaintext(legacy, &users); err != nil {
+				g.passModal.errMsg = err.Error()
+				return
+			}
+			os.Remove(g.legacyUsersPath())
+			g.markSelfWrite()
+			g.logView.Write([]byte("🔐 Migrated legacy plaintext users.json into the encrypted vault\n"))
+		} else if err := g.vault.Save(&users); err != nil {
+			g.passModal.errMsg = err.Error()
+			return
+		} else {
+			g.markSelfWrite()
+		}
+	} else if err := g.vault.Load(&users); err != nil {
+		g.passModal.errMsg = "Incorrect passphrase"
+		g.vault.Lock()
+		return
+	}
+
+	g.usersView.mu.Lock()
+	g.usersView.users = users
+	g.usersView.deleteButtons = make([]widget.Clickable, len(users))
+	g.usersView.mu.Unlock()
+
+	g.passModal = newPassphraseModal()
+	g.vaultUnlocked = true
+	g.w.Invalidate()
+}
+
+// lockVault clears the in-memory SIDs and master key, re-displaying the
+// unlock modal on the next frame.
+func (g *GUI) lockVault() {
+	g.usersView.mu.Lock()
+	g.usersView.users = nil
+	g.usersView.deleteButtons = nil
+	g.usersView.mu.Unlock()
+
+	g.vault.Lock()
+	g.totp.Lock()
+	g.vaultUnlocked = false
+	g.w.Invalidate()
+}
@@ -0,0 +1,197 @@
+package gui
+
+import (
+	"fmt"
+	"image"
+
+	"gioui.org/layout"
+	"gioui.org/op/clip"
+	"gioui.org/op/paint"
+	"gioui.org/unit"
+	"gioui.org/widget"
+	"gioui.org/widget/material"
+
+	"tix-scraper/internal/index"
+)
+
+// WatchesView is the "Watches" tab: a form to save a new watch (name,
+// bleve query string, optional webhook), a list of saved watches with
+// their hit history, and delete buttons. Mirrors OTPView's shape (a form
+// above a list of existing entries).
+type WatchesView struct {
+	gui *GUI
+
+	watches []*index.Watch
+
+	nameEditor    TextField
+	queryEditor   TextField
+	webhookEditor TextField
+	addBtn        widget.Clickable
+	refreshBtn    widget.Clickable
+	deleteButtons []widget.Clickable
+
+	errMsg string
+}
+
+func (wv *WatchesView) refresh() {
+	if wv.gui.watches == nil {
+		wv.watches = nil
+		return
+	}
+	wv.watches = wv.gui.watches.Watches()
+	if len(wv.deleteButtons) != len(wv.watches) {
+		wv.deleteButtons = make([]widget.Clickable, len(wv.watches))
+	}
+}
+
+func (wv *WatchesView) Layout(gtx C) D {
+	if wv.watches == nil {
+		wv.refresh()
+	}
+	if wv.refreshBtn.Clicked(gtx) {
+		wv.refresh()
+	}
+
+	if wv.addBtn.Clicked(gtx) && wv.gui.watches != nil {
+		_, err := wv.gui.watches.AddWatch(index.Watch{
+			Name:    wv.nameEditor.Value(),
+			Query:   wv.queryEditor.Value(),
+			Webhook: wv.webhookEditor.Value(),
+		})
+		if err != nil {
+			wv.errMsg = err.Error()
+		} else {
+			wv.nameEditor.SetValue("")
+			wv.queryEditor.SetValue("")
+			wv.webhookEditor.SetValue("")
+			wv.errMsg = ""
+			wv.refresh()
+		}
+	}
+
+	for i := range wv.deleteButtons {
+		if wv.deleteButtons[i].Clicked(gtx) && wv.gui.watches != nil {
+			if err := wv.gui.watches.RemoveWatch(wv.watches[i].ID); err != nil {
+				wv.errMsg = err.Error()
+			}
+			wv.refresh()
+			break
+		}
+	}
+
+	list := &widget.List{List: layout.List{Axis: layout.Vertical}}
+	return material.List(wv.gui.th, list).Layout(gtx, 1, func(gtx C, _ int) D {
+		return layout.UniformInset(unit.Dp(20)).Layout(gtx, func(gtx C) D {
+			return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+				layout.Rigid(func(gtx C) D {
+					return layout.Flex{Axis: layout.Horizontal, Alignment: layout.Middle}.Layout(gtx,
+						layout.Flexed(1, func(gtx C) D {
+							label := material.H5(wv.gui.th, fmt.Sprintf("👀 Watches (%d)", len(wv.watches)))
+							label.Color = accentColor
+							return label.Layout(gtx)
+						}),
+						layout.Rigid(func(gtx C) D {
+							btn := material.Button(wv.gui.th, &wv.refreshBtn, "🔄 Refresh")
+							btn.Background = accentColor
+							btn.Color = bgColor
+							btn.CornerRadius = unit.Dp(8)
+							return btn.Layout(gtx)
+						}),
+					)
+				}),
+				layout.Rigid(layout.Spacer{Height: unit.Dp(16)}.Layout),
+				layout.Rigid(func(gtx C) D { return wv.layoutAddForm(gtx) }),
+				layout.Rigid(layout.Spacer{Height: unit.Dp(16)}.Layout),
+				layout.Rigid(func(gtx C) D { return wv.layoutWatchList(gtx) }),
+			)
+		})
+	})
+}
+
+func (wv *WatchesView) layoutAddForm(gtx C) D {
+	return widget.Border{Color: borderColor, Width: unit.Dp(1), CornerRadius: unit.Dp(8)}.Layout(gtx, func(gtx C) D {
+		defer clip.UniformRRect(image.Rectangle{Max: gtx.Constraints.Max}, gtx.Dp(8)).Push(gtx.Ops).Pop()
+		paint.Fill(gtx.Ops, cardBg)
+
+		return layout.UniformInset(unit.Dp(12)).Layout(gtx, func(gtx C) D {
+			return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+				layout.Rigid(material.Editor(wv.gui.th, &wv.nameEditor.Editor, "Watch name").Layout),
+				layout.Rigid(layout.Spacer{Height: unit.Dp(8)}.Layout),
+				layout.Rigid(material.Editor(wv.gui.th, &wv.queryEditor.Editor, `Query, e.g. title:"Radiohead MSG" AND price:<350`).Layout),
+				layout.Rigid(layout.Spacer{Height: unit.Dp(8)}.Layout),
+				layout.Rigid(material.Editor(wv.gui.th, &wv.webhookEditor.Editor, "Webhook URL (optional)").Layout),
+				layout.Rigid(layout.Spacer{Height: unit.Dp(8)}.Layout),
+				layout.Rigid(func(gtx C) D {
+					if wv.errMsg != "" {
+						label := material.Caption(wv.gui.th, wv.errMsg)
+						label.Color = dangerColor
+						return label.Layout(gtx)
+					}
+					return D{}
+				}),
+				layout.Rigid(func(gtx C) D {
+					btn := material.Button(wv.gui.th, &wv.addBtn, "➕ Save Watch")
+					btn.Background = accentColor
+					btn.Color = bgColor
+					btn.CornerRadius = unit.Dp(8)
+					return btn.Layout(gtx)
+				}),
+			)
+		})
+	})
+}
+
+func (wv *WatchesView) layoutWatchList(gtx C) D {
+	if len(wv.watches) == 0 {
+		label := material.Body1(wv.gui.th, "No watches saved yet")
+		label.Color = disabledColor
+		return label.Layout(gtx)
+	}
+
+	list := &widget.List{List: layout.List{Axis: layout.Vertical}}
+	return material.List(wv.gui.th, list).Layout(gtx, len(wv.watches), func(gtx C, i int) D {
+		return layout.Inset{Bottom: unit.Dp(8)}.Layout(gtx, func(gtx C) D {
+			return wv.layoutWatchRow(gtx, i)
+		})
+	})
+}
+
+func (wv *WatchesView) layoutWatchRow(gtx C, index int) D {
+	w := wv.watches[index]
+
+	return widget.Border{Color: borderColor, Width: unit.Dp(1), CornerRadius: unit.Dp(8)}.Layout(gtx, func(gtx C) D {
+		defer clip.UniformRRect(image.Rectangle{Max: gtx.Constraints.Max}, gtx.Dp(8)).Push(gtx.Ops).Pop()
+		paint.Fill(gtx.Ops, cardBg)
+
+		return layout.UniformInset(unit.Dp(12)).Layout(gtx, func(gtx C) D {
+			return layout.Flex{Axis: layout.Horizontal, Alignment: layout.Middle}.Layout(gtx,
+				layout.Flexed(1, func(gtx C) D {
+					return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+						layout.Rigid(func(gtx C) D {
+							label := material.Body2(wv.gui.th, w.Name)
+							label.Color = textColor
+							return label.Layout(gtx)
+						}),
+						layout.Rigid(func(gtx C) D {
+							label := material.Caption(wv.gui.th, fmt.Sprintf("%s  |  %d hits", w.Query, len(w.Hits)))
+							label.Color = disabledColor
+							return label.Layout(gtx)
+						}),
+					)
+				}),
+				layout.Rigid(func(gtx C) D {
+					return widget.Border{Color: dangerColor, Width: unit.Dp(1), CornerRadius: unit.Dp(4)}.Layout(gtx, func(gtx C) D {
+						defer clip.UniformRRect(image.Rectangle{Max: gtx.Constraints.Max}, gtx.Dp(4)).Push(gtx.Ops).Pop()
+						return wv.deleteButtons[index].Layout(gtx, func(gtx C) D {
+							return layout.UniformInset(unit.Dp(4)).Layout(gtx, func(gtx C) D {
+								label := material.Caption(wv.gui.th, "✕")
+								label.Color = dangerColor
+								return label.Layout(gtx)
+							})
+						})
+					})
+				}),
+			)
+		})
+	})
+}
@@ -0,0 +1,234 @@
+package index
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/blevesearch/bleve/v2"
+	"github.com/blevesearch/bleve/v2/search/query"
+)
+
+// Index is a persistent, searchable store of every Listing ever scraped,
+// backed by a bleve index on disk at the path passed to Open. It also
+// tracks, per (site, eventID), the set of listings seen as of the last
+// Diff call, so Diff can report what changed since then.
+type Index struct {
+	mu sync.Mutex
+	bi bleve.Index
+
+	// lastSnapshot[site+"|"+eventID] is the key->Listing map as of the
+	// last Diff call for that site/event, used to compute the next Diff.
+	lastSnapshot map[string]map[string]Listing
+}
+
+// Open opens the bleve index at path, creating it with a default dynamic
+// mapping if it doesn't exist yet. A dynamic mapping (bleve infers field
+// types from the indexed struct's JSON tags) is simpler than a hand-built
+// document mapping and is enough for the query-string and numeric-range
+// queries Query needs.
+func Open(path string) (*Index, error) {
+	bi, err := bleve.Open(path)
+	if err == bleve.ErrorIndexPathDoesNotExist {
+		bi, err = bleve.New(path, bleve.NewIndexMapping())
+	}
+	if err != nil {
+		return nil, fmt.Errorf("index: opening bleve index at %s: %w", path, err)
+	}
+
+	return &Index{
+		bi:           bi,
+		lastSnapshot: make(map[string]map[string]Listing),
+	}, nil
+}
+
+func (idx *Index) Close() error {
+	return idx.bi.Close()
+}
+
+// Upsert indexes l, stamping FirstSeen on first sight of its Key and
+// always refreshing LastSeen to now.
+func (idx *Index) Upsert(l Listing) error {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	now := time.Now()
+	if existing, err := idx.get(l.Key()); err == nil {
+		l.FirstSeen = existing.FirstSeen
+	} else {
+		l.FirstSeen = now
+	}
+	l.LastSeen = now
+
+	if err := idx.bi.Index(l.Key(), l); err != nil {
+		return fmt.Errorf("index: upserting listing %s: %w", l.Key(), err)
+	}
+	return nil
+}
+
+// get looks up a single listing by key, bypassing the lock (callers must
+// already hold idx.mu).
+func (idx *Index) get(key string) (Listing, error) {
+	listings, err := idx.search(bleve.NewDocIDQuery([]string{key}))
+	if err != nil {
+		return Listing{}, err
+	}
+	if len(listings) == 0 {
+		return Listing{}, fmt.Errorf("index: no document for key %s", key)
+	}
+	return listings[0], nil
+}
+
+// DiffResult is what Diff reports for one (site, eventID) pair.
+type DiffResult struct {
+	New          []Listing
+	Removed      []Listing
+	PriceChanged []Listing
+}
+
+// Diff compares the listings currently indexed for (site, eventID) against
+// whatever was indexed the last time Diff was called for the same pair
+// (an empty set, the first time), then remembers the current set for next
+// time.
+func (idx *Index) Diff(site, eventID string) (DiffResult, error) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	current, err := idx.listingsFor(site, eventID)
+	if err != nil {
+		return DiffResult{}, fmt.Errorf("index: diffing %s/%s: %w", site, eventID, err)
+	}
+
+	snapshotKey := site + "|" + eventID
+	previous := idx.lastSnapshot[snapshotKey]
+
+	var result DiffResult
+	for key, listing := range current {
+		prior, existed := previous[key]
+		switch {
+		case !existed:
+			result.New = append(result.New, listing)
+		case prior.Price != listing.Price:
+			result.PriceChanged = append(result.PriceChanged, listing)
+		}
+	}
+	for key, listing := range previous {
+		if _, stillThere := current[key]; !stillThere {
+			result.Removed = append(result.Removed, listing)
+		}
+	}
+
+	idx.lastSnapshot[snapshotKey] = current
+	return result, nil
+}
+
+// listingsFor returns every currently-indexed listing for (site, eventID),
+// keyed by Listing.Key(). Callers must hold idx.mu.
+func (idx *Index) listingsFor(site, eventID string) (map[string]Listing, error) {
+	siteQ := bleve.NewMatchQuery(site)
+	siteQ.SetField("site")
+	eventQ := bleve.NewMatchQuery(eventID)
+	eventQ.SetField("event_id")
+	q := bleve.NewConjunctionQuery(siteQ, eventQ)
+	hits, err := idx.search(q)
+	if err != nil {
+		return nil, err
+	}
+
+	listings := make(map[string]Listing, len(hits))
+	for _, l := range hits {
+		listings[l.Key()] = l
+	}
+	return listings, nil
+}
+
+// Filter is a numeric range restriction applied alongside Query's
+// query-string text, e.g. Filter{Field: "price", Max: &max} for
+// "price < max".
+type Filter struct {
+	Field    string
+	Min, Max *float64
+}
+
+func (f Filter) toQuery() query.Query {
+	q := bleve.NewNumericRangeQuery(f.Min, f.Max)
+	q.SetField(f.Field)
+	return q
+}
+
+// Query runs q (bleve's query-string syntax, e.g. `title:"Radiohead MSG"
+// AND price:<350`; field names are Listing's JSON tags) conjoined with any
+// extra Filters, and returns the matching listings.
+func (idx *Index) Query(q string, filters ...Filter) ([]Listing, error) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	queries := []query.Query{bleve.NewQueryStringQuery(q)}
+	for _, f := range filters {
+		queries = append(queries, f.toQuery())
+	}
+
+	return idx.search(bleve.NewConjunctionQuery(queries...))
+}
+
+// search runs q against the index and reconstructs each hit's Listing
+// from its stored fields. Callers must hold idx.mu.
+func (idx *Index) search(q query.Query) ([]Listing, error) {
+	req := bleve.NewSearchRequestOptions(q, 10000, 0, false)
+	req.Fields = []string{"*"}
+
+	result, err := idx.bi.Search(req)
+	if err != nil {
+		return nil, fmt.Errorf("index: search: %w", err)
+	}
+
+	listings := make([]Listing, 0, len(result.Hits))
+	for _, hit := range result.Hits {
+		listings = append(listings, listingFromFields(hit.Fields))
+	}
+	return listings, nil
+}
+
+// listingFromFields reconstructs a Listing from a search hit's stored
+// fields. Bleve returns numeric fields as float64 and date fields as
+// RFC3339 strings regardless of the original Go type, so every field is
+// read defensively with a type switch rather than a direct assertion.
+func listingFromFields(fields map[string]interface{}) Listing {
+	str := func(name string) string {
+		if v, ok := fields[name].(string); ok {
+			return v
+		}
+		return ""
+	}
+	num := func(name string) float64 {
+		switch v := fields[name].(type) {
+		case float64:
+			return v
+		case int:
+			return float64(v)
+		}
+		return 0
+	}
+	at := func(name string) time.Time {
+		if v, ok := fields[name].(string); ok {
+			if t, err := time.Parse(time.RFC3339, v); err == nil {
+				return t
+			}
+		}
+		return time.Time{}
+	}
+
+	return Listing{
+		Site:      str("site"),
+		EventID:   str("event_id"),
+		Section:   str("section"),
+		Row:       str("row"),
+		Seat:      str("seat"),
+		Title:     str("title"),
+		Price:     num("price"),
+		Quantity:  int(num("quantity")),
+		Seller:    str("seller"),
+		FirstSeen: at("first_seen"),
+		LastSeen:  at("last_seen"),
+	}
+}
@@ -0,0 +1,34 @@
+// Package index maintains a persistent, searchable history of scraped
+// ticket listings (index.go, built on github.com/blevesearch/bleve/v2),
+// diffed snapshot-to-snapshot to spot new/removed/price-changed listings,
+// and a watch layer (watch.go) that fires notifications when a saved
+// query starts matching.
+package index
+
+import "time"
+
+// Listing is one (site, event, section, row, seat) combination as seen in
+// a scrape. Site/EventID/Section/Row/Seat together form its identity; the
+// rest is what can change between scrapes.
+type Listing struct {
+	Site    string `json:"site"`
+	EventID string `json:"event_id"`
+	Section string `json:"section"`
+	Row     string `json:"row"`
+	Seat    string `json:"seat"`
+
+	Title    string  `json:"title"` // free-text event title, e.g. "Radiohead MSG"
+	Price    float64 `json:"price"`
+	Quantity int     `json:"quantity"`
+	Seller   string  `json:"seller"`
+
+	FirstSeen time.Time `json:"first_seen"`
+	LastSeen  time.Time `json:"last_seen"`
+}
+
+// Key identifies a Listing independent of anything that can change
+// between scrapes (price, quantity, seller). Two Listings with the same
+// Key are the same seat.
+func (l Listing) Key() string {
+	return l.Site + "|" + l.EventID + "|" + l.Section + "|" + l.Row + "|" + l.Seat
+}
@@ -0,0 +1,272 @@
+package index
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+// Watch is a saved query that gets re-evaluated after every scrape; when a
+// listing starts matching Query that wasn't matching the last time this
+// Watch was evaluated, every registered Notifier is told about it.
+type Watch struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+
+	// Query is a bleve query-string expression, e.g.
+	// `title:"Radiohead MSG" AND price:<350`.
+	Query string `json:"query"`
+
+	// Webhook, if set, is POSTed a JSON body on every new match.
+	Webhook string `json:"webhook,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+	Hits      []Hit     `json:"hits"`
+}
+
+// Hit records one historical match, kept for the UI's hit-history view.
+type Hit struct {
+	Listing Listing   `json:"listing"`
+	Time    time.Time `json:"time"`
+}
+
+// Notifier is told about every newly-matching listing for a Watch. GUI
+// implements this for desktop notifications (see internal/gui), keeping
+// this package decoupled from gui the same way captcha.Prompter keeps
+// captcha decoupled from gui.
+type Notifier interface {
+	Notify(w *Watch, l Listing) error
+}
+
+// Manager owns the set of active Watches, persisted as JSON at persistPath
+// (atomic temp-file-then-rename write, the same pattern netpool.Pool.Save
+// uses), and evaluates them against an Index.
+type Manager struct {
+	idx         *Index
+	persistPath string
+
+	mu        sync.Mutex
+	watches   map[string]*Watch
+	matching  map[string]map[string]bool // watchID -> listing key -> matched as of last Evaluate
+	notifiers []Notifier
+}
+
+// NewManager loads any previously-saved watches from persistPath (a
+// missing file is not an error — first run).
+func NewManager(idx *Index, persistPath string) (*Manager, error) {
+	m := &Manager{
+		idx:         idx,
+		persistPath: persistPath,
+		watches:     make(map[string]*Watch),
+		matching:    make(map[string]map[string]bool),
+	}
+
+	data, err := os.ReadFile(persistPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return m, nil
+		}
+		return nil, fmt.Errorf("index: reading watches from %s: %w", persistPath, err)
+	}
+
+	var watches []*Watch
+	if err := json.Unmarshal(data, &watches); err != nil {
+		return nil, fmt.Errorf("index: parsing watches from %s: %w", persistPath, err)
+	}
+	for _, w := range watches {
+		m.watches[w.ID] = w
+	}
+	return m, nil
+}
+
+// AddNotifier registers a Notifier that Evaluate calls for every new match
+// across every Watch.
+func (m *Manager) AddNotifier(n Notifier) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.notifiers = append(m.notifiers, n)
+}
+
+// AddWatch saves w (assigning it a random ID if empty) and persists the
+// watch set.
+func (m *Manager) AddWatch(w Watch) (*Watch, error) {
+	m.mu.Lock()
+	if w.ID == "" {
+		w.ID = newWatchID()
+	}
+	w.CreatedAt = time.Now()
+	saved := &w
+	m.watches[w.ID] = saved
+	m.mu.Unlock()
+
+	if err := m.save(); err != nil {
+		return nil, err
+	}
+	return saved, nil
+}
+
+// RemoveWatch deletes a watch by ID and persists the watch set.
+func (m *Manager) RemoveWatch(id string) error {
+	m.mu.Lock()
+	delete(m.watches, id)
+	delete(m.matching, id)
+	m.mu.Unlock()
+
+	return m.save()
+}
+
+// Watches returns every registered watch.
+func (m *Manager) Watches() []*Watch {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	watches := make([]*Watch, 0, len(m.watches))
+	for _, w := range m.watches {
+		watches = append(watches, w)
+	}
+	return watches
+}
+
+// Evaluate re-runs every watch's query against idx and notifies for every
+// listing that matches now but didn't the last time Evaluate ran for that
+// watch.
+func (m *Manager) Evaluate() error {
+	m.mu.Lock()
+	watches := make([]*Watch, 0, len(m.watches))
+	for _, w := range m.watches {
+		watches = append(watches, w)
+	}
+	m.mu.Unlock()
+
+	var firstErr error
+	for _, w := range watches {
+		if err := m.evaluateOne(w); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+func (m *Manager) evaluateOne(w *Watch) error {
+	matches, err := m.idx.Query(w.Query)
+	if err != nil {
+		return fmt.Errorf("index: evaluating watch %s: %w", w.ID, err)
+	}
+
+	m.mu.Lock()
+	previouslyMatching := m.matching[w.ID]
+	current := make(map[string]bool, len(matches))
+	var newlyMatching []Listing
+	for _, l := range matches {
+		key := l.Key()
+		current[key] = true
+		if !previouslyMatching[key] {
+			newlyMatching = append(newlyMatching, l)
+		}
+	}
+	m.matching[w.ID] = current
+	notifiers := append([]Notifier(nil), m.notifiers...)
+	m.mu.Unlock()
+
+	if len(newlyMatching) == 0 {
+		return nil
+	}
+
+	m.mu.Lock()
+	for _, l := range newlyMatching {
+		w.Hits = append(w.Hits, Hit{Listing: l, Time: time.Now()})
+	}
+	m.mu.Unlock()
+
+	for _, l := range newlyMatching {
+		for _, n := range notifiers {
+			if err := n.Notify(w, l); err != nil {
+				fmt.Fprintf(os.Stderr, "index: notifier error for watch %s: %v\n", w.ID, err)
+			}
+		}
+	}
+	return m.save()
+}
+
+func (m *Manager) save() error {
+	m.mu.Lock()
+	watches := make([]*Watch, 0, len(m.watches))
+	for _, w := range m.watches {
+		watches = append(watches, w)
+	}
+	m.mu.Unlock()
+
+	data, err := json.MarshalIndent(watches, "", "  ")
+	if err != nil {
+		return fmt.Errorf("index: marshaling watches: %w", err)
+	}
+
+	tmp := m.persistPath + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("index: writing watches temp file: %w", err)
+	}
+	if err := os.Rename(tmp, m.persistPath); err != nil {
+		return fmt.Errorf("index: renaming watches temp file: %w", err)
+	}
+	return nil
+}
+
+func newWatchID() string {
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		return fmt.Sprintf("watch-%d", time.Now().UnixNano())
+	}
+	return "watch-" + hex.EncodeToString(buf)
+}
+
+// WebhookNotifier POSTs a JSON body ({"watch":..., "listing":...}) to
+// w.Webhook for every new match. Watches with no Webhook set are skipped.
+type WebhookNotifier struct {
+	Client *http.Client
+}
+
+func (n WebhookNotifier) Notify(w *Watch, l Listing) error {
+	if w.Webhook == "" {
+		return nil
+	}
+
+	client := n.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	body, err := json.Marshal(struct {
+		Watch   string  `json:"watch"`
+		Listing Listing `json:"listing"`
+	}{Watch: w.Name, Listing: l})
+	if err != nil {
+		return fmt.Errorf("index: marshaling webhook body: %w", err)
+	}
+
+	resp, err := client.Post(w.Webhook, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("index: posting to webhook %s: %w", w.Webhook, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("index: webhook %s returned status %d", w.Webhook, resp.StatusCode)
+	}
+	return nil
+}
+
+// SoundNotifier rings the terminal bell on every match. It's a minimal
+// fallback for operators running the CLI/daemon without the GUI open; it
+// doesn't depend on any audio library since this repo doesn't carry one.
+type SoundNotifier struct{}
+
+func (SoundNotifier) Notify(w *Watch, l Listing) error {
+	fmt.Fprint(os.Stdout, "\a")
+	return nil
+}
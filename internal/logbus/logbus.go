@@ -0,0 +1,660 @@
+// Package logbus is the structured logging subsystem shared by every
+// frontend tix-scraper can present a bot run through — the Gio GUI and the
+// terminal UI (internal/tui) both publish to and read from the same
+// LogBus, so "start bot from the TUI, watch its logs in the GUI" (or vice
+// versa) is just two LogSinks registered on one bus.
+package logbus
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"image/color"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultRingCapacity is how many entries LogRingBuffer keeps in memory
+// before dropping the oldest; older entries still live in whatever file or
+// JSON-lines sinks are registered.
+const DefaultRingCapacity = 10000
+
+// LogLevel classifies a LogEntry so sinks can color, threshold, or filter
+// on it instead of treating every line as opaque text.
+type LogLevel int
+
+const (
+	LevelDebug LogLevel = iota
+	LevelInfo
+	LevelWarn
+	LevelError
+)
+
+// String returns the short badge text shown in the GUI log list.
+func (l LogLevel) String() string {
+	switch l {
+	case LevelDebug:
+		return "DBG"
+	case LevelWarn:
+		return "WARN"
+	case LevelError:
+		return "ERR"
+	default:
+		return "INFO"
+	}
+}
+
+// Name returns the full lowercase level name used in file sinks.
+func (l LogLevel) Name() string {
+	switch l {
+	case LevelDebug:
+		return "debug"
+	case LevelWarn:
+		return "warn"
+	case LevelError:
+		return "error"
+	default:
+		return "info"
+	}
+}
+
+func (l LogLevel) MarshalJSON() ([]byte, error) {
+	return json.Marshal(l.Name())
+}
+
+// LogEntry is one structured log line flowing through a LogBus. BotName is
+// empty for frontend-level messages not tied to a specific bot.
+type LogEntry struct {
+	Timestamp time.Time      `json:"timestamp"`
+	BotName   string         `json:"bot_name,omitempty"`
+	Level     LogLevel       `json:"level"`
+	Message   string         `json:"message"`
+	Fields    map[string]any `json:"fields,omitempty"`
+
+	// Spans holds Message re-split into styled runs when the raw input
+	// carried ANSI SGR escape sequences (as emitted by libraries like
+	// fatih/color or logrus); it is nil otherwise. Message is always the
+	// plain, escape-free text, so sinks that don't care about styling
+	// (file, JSON-lines, CSV) never need to look at Spans.
+	Spans []StyledSpan `json:"-"`
+}
+
+// LogSink receives every entry published on a LogBus. Implementations must
+// be safe to call concurrently and should not block the caller for long.
+type LogSink interface {
+	Publish(entry LogEntry) error
+}
+
+// LogBus fans out LogEntry values to every registered sink: a frontend's
+// own log list, a rotating file per bot, and an optional JSON-lines file
+// for post-mortem analysis. A sink's own error is logged to stderr rather
+// than propagated, so one misbehaving sink can't stop the others from
+// receiving an entry.
+type LogBus struct {
+	mu    sync.Mutex
+	sinks []LogSink
+}
+
+// NewBus returns an empty bus; call AddSink to attach sinks to it.
+func NewBus() *LogBus {
+	return &LogBus{}
+}
+
+// AddSink registers a sink to receive every future Publish call.
+func (b *LogBus) AddSink(sink LogSink) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.sinks = append(b.sinks, sink)
+}
+
+// RemoveSink unregisters a sink added with AddSink, comparing by identity.
+// It's a no-op if sink was never registered (or already removed) — callers
+// like a closed HTTP streaming connection shouldn't have to track whether
+// their own cleanup already ran.
+func (b *LogBus) RemoveSink(sink LogSink) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for i, s := range b.sinks {
+		if s == sink {
+			b.sinks = append(b.sinks[:i], b.sinks[i+1:]...)
+			return
+		}
+	}
+}
+
+// Publish fans entry out to every registered sink.
+func (b *LogBus) Publish(entry LogEntry) {
+	b.mu.Lock()
+	sinks := make([]LogSink, len(b.sinks))
+	copy(sinks, b.sinks)
+	b.mu.Unlock()
+
+	for _, sink := range sinks {
+		if err := sink.Publish(entry); err != nil {
+			fmt.Fprintf(os.Stderr, "log sink error: %v\n", err)
+		}
+	}
+}
+
+// Infof, Warnf, Errorf and Debugf let callers publish a structured entry
+// directly instead of formatting a line for a Write([]byte)-based adapter
+// (e.g. gui.BotLogWriter) to parse back into a level.
+func (b *LogBus) Infof(botName, format string, args ...any) {
+	b.publishf(botName, LevelInfo, format, args...)
+}
+
+func (b *LogBus) Warnf(botName, format string, args ...any) {
+	b.publishf(botName, LevelWarn, format, args...)
+}
+
+func (b *LogBus) Errorf(botName, format string, args ...any) {
+	b.publishf(botName, LevelError, format, args...)
+}
+
+func (b *LogBus) Debugf(botName, format string, args ...any) {
+	b.publishf(botName, LevelDebug, format, args...)
+}
+
+func (b *LogBus) publishf(botName string, level LogLevel, format string, args ...any) {
+	b.Publish(LogEntry{
+		Timestamp: time.Now(),
+		BotName:   botName,
+		Level:     level,
+		Message:   fmt.Sprintf(format, args...),
+	})
+}
+
+// ParseEntry recovers a LogEntry from the free-text lines the rest of the
+// codebase still writes via an io.Writer (a "[botName] message" prefix,
+// and one of the emoji this codebase already uses consistently to mean
+// success/failure/caution).
+func ParseEntry(p []byte) LogEntry {
+	msg := strings.TrimSuffix(string(p), "\n")
+	botName, rest := splitBotPrefix(msg)
+	plain, spans := parseANSI(rest)
+	return LogEntry{
+		Timestamp: time.Now(),
+		BotName:   botName,
+		Level:     guessLevel(plain),
+		Message:   plain,
+		Spans:     spans,
+	}
+}
+
+func splitBotPrefix(msg string) (botName, rest string) {
+	if strings.HasPrefix(msg, "[") {
+		if end := strings.Index(msg, "]"); end > 0 {
+			return msg[1:end], strings.TrimSpace(msg[end+1:])
+		}
+	}
+	return "", msg
+}
+
+var (
+	errorMarkers = []string{"❌"}
+	warnMarkers  = []string{"⚠️", "🛑", "⏹️"}
+	debugMarkers = []string{"🔍", "🐛"}
+)
+
+func guessLevel(msg string) LogLevel {
+	for _, m := range errorMarkers {
+		if strings.Contains(msg, m) {
+			return LevelError
+		}
+	}
+	for _, m := range warnMarkers {
+		if strings.Contains(msg, m) {
+			return LevelWarn
+		}
+	}
+	for _, m := range debugMarkers {
+		if strings.Contains(msg, m) {
+			return LevelDebug
+		}
+	}
+	return LevelInfo
+}
+
+// StyledSpan is one contiguously-styled run of text recovered from an ANSI
+// SGR escape sequence run. Only the attributes this codebase's frontends
+// can actually render are kept: foreground/background color, bold, and
+// underline.
+type StyledSpan struct {
+	Text      string
+	FG        color.NRGBA
+	BG        color.NRGBA
+	HasFG     bool
+	HasBG     bool
+	Bold      bool
+	Underline bool
+}
+
+var ansiSGR = regexp.MustCompile("\x1b\\[([0-9;]*)m")
+
+// parseANSI strips ANSI SGR ("\x1b[...m") escape sequences out of raw,
+// returning the plain text plus the styled runs they described. It
+// returns a nil spans slice (not an empty one) when raw carries no escape
+// sequences at all, so callers can cheaply tell "plain text" from "one
+// span with no styling" and skip rendering spans entirely in the common
+// case.
+func parseANSI(raw string) (plain string, spans []StyledSpan) {
+	if !strings.Contains(raw, "\x1b[") {
+		return raw, nil
+	}
+
+	var out strings.Builder
+	cur := StyledSpan{}
+	last := 0
+	for _, m := range ansiSGR.FindAllStringSubmatchIndex(raw, -1) {
+		start, end, codeStart, codeEnd := m[0], m[1], m[2], m[3]
+		if text := raw[last:start]; text != "" {
+			out.WriteString(text)
+			span := cur
+			span.Text = text
+			spans = append(spans, span)
+		}
+		applySGR(&cur, raw[codeStart:codeEnd])
+		last = end
+	}
+	if tail := raw[last:]; tail != "" {
+		out.WriteString(tail)
+		span := cur
+		span.Text = tail
+		spans = append(spans, span)
+	}
+	return out.String(), spans
+}
+
+// applySGR updates span in place for one "m"-terminated sequence's
+// semicolon-separated codes, consuming the extra parameters that 256-color
+// and truecolor codes (38/48;5;n and 38/48;2;r;g;b) carry.
+func applySGR(span *StyledSpan, codes string) {
+	parts := strings.Split(codes, ";")
+	for i := 0; i < len(parts); i++ {
+		code, err := strconv.Atoi(parts[i])
+		if err != nil {
+			continue
+		}
+		switch {
+		case code == 0:
+			*span = StyledSpan{}
+		case code == 1:
+			span.Bold = true
+		case code == 4:
+			span.Underline = true
+		case code == 22:
+			span.Bold = false
+		case code == 24:
+			span.Underline = false
+		case code == 39:
+			span.HasFG = false
+		case code == 49:
+			span.HasBG = false
+		case code == 38 || code == 48:
+			c, consumed := parseExtendedColor(parts[i+1:])
+			i += consumed
+			if code == 38 {
+				span.FG, span.HasFG = c, true
+			} else {
+				span.BG, span.HasBG = c, true
+			}
+		case code >= 30 && code <= 37:
+			span.FG, span.HasFG = ansi16Palette[code-30], true
+		case code >= 90 && code <= 97:
+			span.FG, span.HasFG = ansi16Palette[code-90+8], true
+		case code >= 40 && code <= 47:
+			span.BG, span.HasBG = ansi16Palette[code-40], true
+		case code >= 100 && code <= 107:
+			span.BG, span.HasBG = ansi16Palette[code-100+8], true
+		}
+	}
+}
+
+// parseExtendedColor consumes the parameters following a 38 or 48 code
+// (either "5;n" for a 256-color index or "2;r;g;b" for truecolor) and
+// reports how many extra parts it consumed.
+func parseExtendedColor(parts []string) (c color.NRGBA, consumed int) {
+	if len(parts) == 0 {
+		return color.NRGBA{}, 0
+	}
+	mode, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return color.NRGBA{}, 0
+	}
+	switch mode {
+	case 5:
+		if len(parts) < 2 {
+			return color.NRGBA{}, 1
+		}
+		n, _ := strconv.Atoi(parts[1])
+		return ansi256Color(n), 2
+	case 2:
+		if len(parts) < 4 {
+			return color.NRGBA{}, len(parts) - 1
+		}
+		r, _ := strconv.Atoi(parts[1])
+		g, _ := strconv.Atoi(parts[2])
+		b, _ := strconv.Atoi(parts[3])
+		return color.NRGBA{R: uint8(r), G: uint8(g), B: uint8(b), A: 255}, 4
+	default:
+		return color.NRGBA{}, 0
+	}
+}
+
+// ansi16Palette is the standard (non-bright) 8-color ANSI palette followed
+// by its bright variants, indexed 0-15.
+var ansi16Palette = [16]color.NRGBA{
+	{R: 0, G: 0, B: 0, A: 255},
+	{R: 205, G: 49, B: 49, A: 255},
+	{R: 13, G: 188, B: 121, A: 255},
+	{R: 229, G: 229, B: 16, A: 255},
+	{R: 36, G: 114, B: 200, A: 255},
+	{R: 188, G: 63, B: 188, A: 255},
+	{R: 17, G: 168, B: 205, A: 255},
+	{R: 229, G: 229, B: 229, A: 255},
+	{R: 102, G: 102, B: 102, A: 255},
+	{R: 241, G: 76, B: 76, A: 255},
+	{R: 35, G: 209, B: 139, A: 255},
+	{R: 245, G: 245, B: 67, A: 255},
+	{R: 59, G: 142, B: 234, A: 255},
+	{R: 214, G: 112, B: 214, A: 255},
+	{R: 41, G: 184, B: 219, A: 255},
+	{R: 255, G: 255, B: 255, A: 255},
+}
+
+// ansi256Color approximates xterm's 256-color palette: 0-15 are the
+// standard 16 colors, 16-231 are a 6x6x6 color cube, and 232-255 are a
+// grayscale ramp.
+func ansi256Color(n int) color.NRGBA {
+	switch {
+	case n < 0 || n > 255:
+		return color.NRGBA{A: 255}
+	case n < 16:
+		return ansi16Palette[n]
+	case n < 232:
+		n -= 16
+		levels := [6]uint8{0, 95, 135, 175, 215, 255}
+		r := levels[(n/36)%6]
+		g := levels[(n/6)%6]
+		b := levels[n%6]
+		return color.NRGBA{R: r, G: g, B: b, A: 255}
+	default:
+		v := uint8(8 + (n-232)*10)
+		return color.NRGBA{R: v, G: v, B: v, A: 255}
+	}
+}
+
+// BotColor deterministically maps botName to a distinct, readable color by
+// hashing it into a hue on a fixed-saturation/lightness HSL wheel, so the
+// same bot keeps the same color across the whole run (and across
+// restarts, since it isn't assigned in registration order) without a
+// central color-assignment table to keep in sync.
+func BotColor(botName string) color.NRGBA {
+	h := fnv.New32a()
+	h.Write([]byte(botName))
+	hue := float64(h.Sum32() % 360)
+	return hslToRGBA(hue, 0.55, 0.55)
+}
+
+// hslToRGBA converts HSL (hue in degrees, saturation/lightness in [0,1])
+// to an opaque color.NRGBA.
+func hslToRGBA(h, s, l float64) color.NRGBA {
+	c := (1 - abs(2*l-1)) * s
+	x := c * (1 - abs(mod(h/60, 2)-1))
+	m := l - c/2
+
+	var r, g, b float64
+	switch {
+	case h < 60:
+		r, g, b = c, x, 0
+	case h < 120:
+		r, g, b = x, c, 0
+	case h < 180:
+		r, g, b = 0, c, x
+	case h < 240:
+		r, g, b = 0, x, c
+	case h < 300:
+		r, g, b = x, 0, c
+	default:
+		r, g, b = c, 0, x
+	}
+
+	return color.NRGBA{
+		R: uint8((r + m) * 255),
+		G: uint8((g + m) * 255),
+		B: uint8((b + m) * 255),
+		A: 255,
+	}
+}
+
+func abs(f float64) float64 {
+	if f < 0 {
+		return -f
+	}
+	return f
+}
+
+func mod(f float64, m float64) float64 {
+	for f >= m {
+		f -= m
+	}
+	return f
+}
+
+// RotatingFileSink writes one text log file per bot (entries with no
+// BotName go to "_app.log"), rotating a file to ".1" once it grows past
+// maxFileSize so a long-running bot can't fill the disk.
+type RotatingFileSink struct {
+	dir         string
+	maxFileSize int64
+
+	mu    sync.Mutex
+	files map[string]*os.File
+}
+
+func NewRotatingFileSink(dir string) *RotatingFileSink {
+	return &RotatingFileSink{
+		dir:         dir,
+		maxFileSize: 5 * 1024 * 1024,
+		files:       make(map[string]*os.File),
+	}
+}
+
+func (s *RotatingFileSink) Publish(entry LogEntry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	name := entry.BotName
+	if name == "" {
+		name = "_app"
+	}
+
+	f, err := s.fileFor(name)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(f, "%s [%s] %s\n", entry.Timestamp.Format("2006-01-02 15:04:05"), entry.Level, entry.Message)
+	return err
+}
+
+func (s *RotatingFileSink) fileFor(name string) (*os.File, error) {
+	path := filepath.Join(s.dir, sanitizeLogFileName(name)+".log")
+
+	if f, ok := s.files[name]; ok {
+		if info, err := f.Stat(); err == nil && info.Size() > s.maxFileSize {
+			f.Close()
+			delete(s.files, name)
+			os.Rename(path, path+".1")
+		} else {
+			return f, nil
+		}
+	}
+
+	if err := os.MkdirAll(s.dir, 0700); err != nil {
+		return nil, err
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, err
+	}
+	s.files[name] = f
+	return f, nil
+}
+
+func sanitizeLogFileName(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_':
+			return r
+		default:
+			return '_'
+		}
+	}, name)
+}
+
+// JSONLSink appends every entry, across all bots, as one JSON object per
+// line — meant for post-mortem analysis with jq or similar, not display.
+type JSONLSink struct {
+	mu sync.Mutex
+	f  *os.File
+}
+
+func NewJSONLSink(path string) (*JSONLSink, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, err
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, err
+	}
+	return &JSONLSink{f: f}, nil
+}
+
+func (s *JSONLSink) Publish(entry LogEntry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+	_, err = s.f.Write(data)
+	return err
+}
+
+// LogRingBuffer is a fixed-capacity, oldest-drops-first store of LogEntry
+// values. It backs a frontend's in-memory history so a long scraping run
+// can't grow memory use without bound.
+type LogRingBuffer struct {
+	mu       sync.Mutex
+	capacity int
+	entries  []LogEntry
+	start    int
+	size     int
+}
+
+// NewLogRingBuffer returns a buffer holding at most capacity entries. A
+// non-positive capacity is replaced with DefaultRingCapacity.
+func NewLogRingBuffer(capacity int) *LogRingBuffer {
+	if capacity <= 0 {
+		capacity = DefaultRingCapacity
+	}
+	return &LogRingBuffer{
+		capacity: capacity,
+		entries:  make([]LogEntry, capacity),
+	}
+}
+
+// Add stores entry, reports whether it overwrote (and thus dropped) the
+// buffer's oldest entry.
+func (r *LogRingBuffer) Add(entry LogEntry) (overwrote bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	idx := (r.start + r.size) % r.capacity
+	if r.size == r.capacity {
+		r.start = (r.start + 1) % r.capacity
+		overwrote = true
+	} else {
+		r.size++
+	}
+	r.entries[idx] = entry
+	return overwrote
+}
+
+// Snapshot returns a copy of every entry currently held, oldest first.
+func (r *LogRingBuffer) Snapshot() []LogEntry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	out := make([]LogEntry, r.size)
+	for i := 0; i < r.size; i++ {
+		out[i] = r.entries[(r.start+i)%r.capacity]
+	}
+	return out
+}
+
+// Len reports how many entries are currently held (never more than the
+// buffer's capacity).
+func (r *LogRingBuffer) Len() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.size
+}
+
+// Export formats understood by ExportEntries.
+const (
+	FormatPlain     = "plain"
+	FormatJSONLines = "json-lines"
+	FormatCSV       = "csv"
+)
+
+// ExportEntries writes entries to w in one of the formats above.
+func ExportEntries(w io.Writer, format string, entries []LogEntry) error {
+	switch format {
+	case FormatJSONLines:
+		enc := json.NewEncoder(w)
+		for _, entry := range entries {
+			if err := enc.Encode(entry); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case FormatCSV:
+		cw := csv.NewWriter(w)
+		if err := cw.Write([]string{"timestamp", "bot_name", "level", "message"}); err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			row := []string{entry.Timestamp.Format(time.RFC3339), entry.BotName, entry.Level.Name(), entry.Message}
+			if err := cw.Write(row); err != nil {
+				return err
+			}
+		}
+		cw.Flush()
+		return cw.Error()
+
+	default: // FormatPlain
+		for _, entry := range entries {
+			line := fmt.Sprintf("%s [%s]", entry.Timestamp.Format("2006-01-02 15:04:05"), entry.Level)
+			if entry.BotName != "" {
+				line += " [" + entry.BotName + "]"
+			}
+			line += " " + entry.Message + "\n"
+			if _, err := io.WriteString(w, line); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
@@ -0,0 +1,291 @@
+// Package metrics is a small, dependency-free Prometheus text-exposition
+// registry for RunScraper's counters, histograms, and gauges. It follows
+// the same hand-rolled-rather-than-client_golang precedent as
+// internal/scheduler's PrometheusExporter, but as a general-purpose
+// registry instead of one wired to a single event channel: RunScraper's
+// instrumentation comes from several unrelated call sites (the URL
+// router, the captcha retry loop, a booking publish) rather than one
+// stream of events.
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Registry holds every counter, histogram, and gauge created through it
+// and serves them all via Handler.
+type Registry struct {
+	mu         sync.Mutex
+	counters   []*CounterVec
+	histograms []*HistogramVec
+	gauges     []*GaugeVec
+}
+
+// NewRegistry returns an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// DefaultRegistry is the registry RunScraper's package-level metrics below
+// are created on; mount it at /metrics via DefaultRegistry.Handler().
+var DefaultRegistry = NewRegistry()
+
+// NewCounterVec creates and registers a counter labeled by labelNames.
+func (r *Registry) NewCounterVec(name, help string, labelNames ...string) *CounterVec {
+	c := &CounterVec{
+		name:       name,
+		help:       help,
+		labelNames: labelNames,
+		values:     make(map[string]*counterEntry),
+	}
+	r.mu.Lock()
+	r.counters = append(r.counters, c)
+	r.mu.Unlock()
+	return c
+}
+
+// NewHistogramVec creates and registers a histogram labeled by labelNames,
+// bucketed by the (ascending) bounds in buckets; a final +Inf bucket is
+// implied.
+func (r *Registry) NewHistogramVec(name, help string, buckets []float64, labelNames ...string) *HistogramVec {
+	h := &HistogramVec{
+		name:       name,
+		help:       help,
+		buckets:    buckets,
+		labelNames: labelNames,
+		values:     make(map[string]*histogramEntry),
+	}
+	r.mu.Lock()
+	r.histograms = append(r.histograms, h)
+	r.mu.Unlock()
+	return h
+}
+
+// NewGaugeVec creates and registers a gauge labeled by labelNames.
+func (r *Registry) NewGaugeVec(name, help string, labelNames ...string) *GaugeVec {
+	g := &GaugeVec{
+		name:       name,
+		help:       help,
+		labelNames: labelNames,
+		values:     make(map[string]*gaugeEntry),
+	}
+	r.mu.Lock()
+	r.gauges = append(r.gauges, g)
+	r.mu.Unlock()
+	return g
+}
+
+// Handler returns an http.Handler serving every metric registered on r in
+// Prometheus text-exposition format, e.g.
+// http.Handle("/metrics", metrics.DefaultRegistry.Handler()).
+func (r *Registry) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		r.mu.Lock()
+		counters := append([]*CounterVec(nil), r.counters...)
+		histograms := append([]*HistogramVec(nil), r.histograms...)
+		gauges := append([]*GaugeVec(nil), r.gauges...)
+		r.mu.Unlock()
+
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+		for _, c := range counters {
+			c.writeTo(w)
+		}
+		for _, h := range histograms {
+			h.writeTo(w)
+		}
+		for _, g := range gauges {
+			g.writeTo(w)
+		}
+	})
+}
+
+func labelKey(labelValues []string) string {
+	return strings.Join(labelValues, "\x1f")
+}
+
+func labelString(labelNames, labelValues []string) string {
+	if len(labelNames) == 0 {
+		return ""
+	}
+	parts := make([]string, len(labelNames))
+	for i, n := range labelNames {
+		parts[i] = fmt.Sprintf("%s=%q", n, labelValues[i])
+	}
+	return "{" + strings.Join(parts, ",") + "}"
+}
+
+func labelStringWithExtra(labelNames, labelValues []string, extraName, extraValue string) string {
+	parts := make([]string, 0, len(labelNames)+1)
+	for i, n := range labelNames {
+		parts = append(parts, fmt.Sprintf("%s=%q", n, labelValues[i]))
+	}
+	parts = append(parts, fmt.Sprintf("%s=%q", extraName, extraValue))
+	return "{" + strings.Join(parts, ",") + "}"
+}
+
+// CounterVec is a monotonically increasing counter, broken down by label
+// values.
+type CounterVec struct {
+	mu         sync.Mutex
+	name, help string
+	labelNames []string
+	values     map[string]*counterEntry
+}
+
+type counterEntry struct {
+	labelValues []string
+	value       float64
+}
+
+// Inc increments the counter for labelValues by 1.
+func (c *CounterVec) Inc(labelValues ...string) {
+	c.Add(1, labelValues...)
+}
+
+// Add increments the counter for labelValues by delta.
+func (c *CounterVec) Add(delta float64, labelValues ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := labelKey(labelValues)
+	e, ok := c.values[key]
+	if !ok {
+		e = &counterEntry{labelValues: append([]string(nil), labelValues...)}
+		c.values[key] = e
+	}
+	e.value += delta
+}
+
+func (c *CounterVec) writeTo(w io.Writer) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	keys := make([]string, 0, len(c.values))
+	for k := range c.values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	fmt.Fprintf(w, "# HELP %s %s\n", c.name, c.help)
+	fmt.Fprintf(w, "# TYPE %s counter\n", c.name)
+	for _, k := range keys {
+		e := c.values[k]
+		fmt.Fprintf(w, "%s%s %g\n", c.name, labelString(c.labelNames, e.labelValues), e.value)
+	}
+}
+
+// GaugeVec is a value that can move up or down, broken down by label
+// values.
+type GaugeVec struct {
+	mu         sync.Mutex
+	name, help string
+	labelNames []string
+	values     map[string]*gaugeEntry
+}
+
+type gaugeEntry struct {
+	labelValues []string
+	value       float64
+}
+
+// Set records value as the current reading for labelValues.
+func (g *GaugeVec) Set(value float64, labelValues ...string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	key := labelKey(labelValues)
+	e, ok := g.values[key]
+	if !ok {
+		e = &gaugeEntry{labelValues: append([]string(nil), labelValues...)}
+		g.values[key] = e
+	}
+	e.value = value
+}
+
+func (g *GaugeVec) writeTo(w io.Writer) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	keys := make([]string, 0, len(g.values))
+	for k := range g.values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	fmt.Fprintf(w, "# HELP %s %s\n", g.name, g.help)
+	fmt.Fprintf(w, "# TYPE %s gauge\n", g.name)
+	for _, k := range keys {
+		e := g.values[k]
+		fmt.Fprintf(w, "%s%s %g\n", g.name, labelString(g.labelNames, e.labelValues), e.value)
+	}
+}
+
+// HistogramVec observes float64 samples into cumulative buckets, broken
+// down by label values.
+type HistogramVec struct {
+	mu         sync.Mutex
+	name, help string
+	buckets    []float64
+	labelNames []string
+	values     map[string]*histogramEntry
+}
+
+type histogramEntry struct {
+	labelValues  []string
+	bucketCounts []uint64
+	sum          float64
+	count        uint64
+}
+
+// Observe records value, bucketing it under every configured bound >=
+// value (and the implied +Inf bucket).
+func (h *HistogramVec) Observe(value float64, labelValues ...string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	key := labelKey(labelValues)
+	e, ok := h.values[key]
+	if !ok {
+		e = &histogramEntry{
+			labelValues:  append([]string(nil), labelValues...),
+			bucketCounts: make([]uint64, len(h.buckets)),
+		}
+		h.values[key] = e
+	}
+	for i, bound := range h.buckets {
+		if value <= bound {
+			e.bucketCounts[i]++
+		}
+	}
+	e.sum += value
+	e.count++
+}
+
+func (h *HistogramVec) writeTo(w io.Writer) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	keys := make([]string, 0, len(h.values))
+	for k := range h.values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	fmt.Fprintf(w, "# HELP %s %s\n", h.name, h.help)
+	fmt.Fprintf(w, "# TYPE %s histogram\n", h.name)
+	for _, k := range keys {
+		e := h.values[k]
+		for i, bound := range h.buckets {
+			le := fmt.Sprintf("%g", bound)
+			fmt.Fprintf(w, "%s_bucket%s %d\n", h.name, labelStringWithExtra(h.labelNames, e.labelValues, "le", le), e.bucketCounts[i])
+		}
+		fmt.Fprintf(w, "%s_bucket%s %d\n", h.name, labelStringWithExtra(h.labelNames, e.labelValues, "le", "+Inf"), e.count)
+		fmt.Fprintf(w, "%s_sum%s %g\n", h.name, labelString(h.labelNames, e.labelValues), e.sum)
+		fmt.Fprintf(w, "%s_count%s %d\n", h.name, labelString(h.labelNames, e.labelValues), e.count)
+	}
+}
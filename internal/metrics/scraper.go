@@ -0,0 +1,56 @@
+package metrics
+
+// defaultLatencyBuckets covers the page-transition latencies seen in
+// practice: sub-second XHRs up through multi-second full navigations.
+var defaultLatencyBuckets = []float64{0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30}
+
+// Scraper-wide metrics, registered on DefaultRegistry and mounted at
+// /metrics so operators can Grafana-dashboard multiple concurrent scraper
+// instances during a ticket war.
+var (
+	// Iterations counts each RunScraper loop iteration, labeled by its
+	// outcome ("success" or "error").
+	Iterations = DefaultRegistry.NewCounterVec(
+		"tix_iterations_total",
+		"Scraper loop iterations, by result.",
+		"result",
+	)
+
+	// CaptchaAttempts counts each captcha solve-and-submit attempt in
+	// processTicketPage's retry loop, labeled by outcome ("solved",
+	// "solve_error", "rejected"). executeBookingFlow's captcha loop does
+	// not count its attempts here.
+	CaptchaAttempts = DefaultRegistry.NewCounterVec(
+		"tix_captcha_attempts_total",
+		"Captcha solve attempts, by outcome.",
+		"outcome",
+	)
+
+	// PageTransitions observes, in seconds, how long runMainFlow's URL
+	// router spent on a page before routing to the next one, labeled by
+	// the from/to URL pattern.
+	PageTransitions = DefaultRegistry.NewHistogramVec(
+		"tix_page_transition_seconds",
+		"Time runMainFlow's URL router spent on a page before its next transition.",
+		defaultLatencyBuckets,
+		"from", "to",
+	)
+
+	// BookingSuccess counts each successfully completed Booking, labeled
+	// by event ID.
+	BookingSuccess = DefaultRegistry.NewCounterVec(
+		"tix_booking_success_total",
+		"Bookings completed successfully, by event ID.",
+		"event_id",
+	)
+
+	// CurrentURL is set to 1 for the URL pattern runMainFlow's router is
+	// currently handling in a given iteration, labeled by iteration
+	// number and URL pattern, so a dashboard can show where each
+	// concurrent instance is stuck.
+	CurrentURL = DefaultRegistry.NewGaugeVec(
+		"tix_current_url",
+		"1 for the URL pattern the scraper is currently on, by iteration.",
+		"iteration", "url",
+	)
+)
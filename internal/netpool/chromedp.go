@@ -0,0 +1,40 @@
+package netpool
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/chromedp/chromedp"
+)
+
+// WithPool acquires a lease from p for siteTag and returns a
+// chromedp.ExecAllocatorOption that sets --proxy-server to the leased
+// proxy, so a scraper only needs to add one option to getBrowserContext's
+// existing chromedp.DefaultExecAllocatorOptions. If p has no registered
+// proxies at all (Acquire still hands out unhealthy ones by LRU), it logs
+// and falls back to no proxy rather than
+// failing the allocator construction outright — a scraper without a
+// configured pool should behave exactly as it did before this package
+// existed.
+//
+// The returned Lease is not reported on automatically; callers that want
+// scoring feedback must keep the lease (WithPoolLease does this) and call
+// Report once they know the outcome of the session that used it.
+func WithPool(p *Pool, siteTag string) chromedp.ExecAllocatorOption {
+	opt, _ := WithPoolLease(p, siteTag)
+	return opt
+}
+
+// WithPoolLease is WithPool but also returns the acquired Lease (nil if
+// none could be acquired) so the caller can Report its outcome once the
+// browser session using it finishes.
+func WithPoolLease(p *Pool, siteTag string) (chromedp.ExecAllocatorOption, *Lease) {
+	lease, err := p.Acquire(context.Background(), siteTag)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "netpool: could not acquire a proxy for %q: %v\n", siteTag, err)
+		return func(*chromedp.ExecAllocator) {}, nil
+	}
+
+	return chromedp.ProxyServer(lease.URL().String()), lease
+}
@@ -0,0 +1,59 @@
+package netpool
+
+import (
+	"fmt"
+	"net"
+)
+
+// cidrCursor streams the host addresses of a CIDR range one at a time
+// instead of materializing them into a slice, so expanding a /16 (or
+// larger) doesn't allocate 65k+ net.IP values up front.
+type cidrCursor struct {
+	ip      net.IP // next address to emit, advanced in place
+	network *net.IPNet
+	done    bool
+}
+
+// newCIDRCursor parses cidr (e.g. "10.0.0.0/24") and positions the cursor
+// at the first address in the range.
+func newCIDRCursor(cidr string) (*cidrCursor, error) {
+	ip, network, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return nil, fmt.Errorf("netpool: parsing CIDR %q: %w", cidr, err)
+	}
+
+	start := ip.Mask(network.Mask)
+	return &cidrCursor{ip: start, network: network}, nil
+}
+
+// Next returns the next address in the range and advances the cursor, or
+// (nil, false) once every address in the range has been emitted.
+func (c *cidrCursor) Next() (net.IP, bool) {
+	if c.done {
+		return nil, false
+	}
+
+	current := make(net.IP, len(c.ip))
+	copy(current, c.ip)
+
+	if !incrementIP(c.ip) || !c.network.Contains(c.ip) {
+		c.done = true
+	}
+
+	return current, true
+}
+
+// incrementIP adds 1 to ip in place (big-endian byte arithmetic with
+// carry). It returns true when the result still fits the address's byte
+// width, and false when the increment overflowed it (e.g.
+// 255.255.255.255 -> 0.0.0.0).
+func incrementIP(ip net.IP) bool {
+	for i := len(ip) - 1; i >= 0; i-- {
+		ip[i]++
+		if ip[i] != 0 {
+			return true
+		}
+		// ip[i] wrapped to 0; carry into the next byte to the left.
+	}
+	return false
+}
@@ -0,0 +1,89 @@
+package netpool
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+)
+
+// Prober periodically HEAD-checks every proxy in a Pool against a target
+// origin and ejects ones that fail outright, so a scraper run doesn't keep
+// picking proxies that died since the last session.
+type Prober struct {
+	pool     *Pool
+	target   string // origin to HEAD-check through each proxy, e.g. "https://tixcraft.com"
+	interval time.Duration
+	timeout  time.Duration
+}
+
+// NewProber returns a Prober that checks pool's entries against target
+// every interval.
+func NewProber(pool *Pool, target string, interval time.Duration) *Prober {
+	return &Prober{pool: pool, target: target, interval: interval, timeout: 10 * time.Second}
+}
+
+// Run blocks, probing on a ticker until ctx is cancelled. Callers typically
+// start it with `go prober.Run(ctx)` alongside the scraper's own lifecycle.
+func (pr *Prober) Run(ctx context.Context) {
+	ticker := time.NewTicker(pr.interval)
+	defer ticker.Stop()
+
+	pr.probeAll(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			pr.probeAll(ctx)
+		}
+	}
+}
+
+func (pr *Prober) probeAll(ctx context.Context) {
+	for _, rawURL := range pr.pool.Snapshot() {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+
+		if err := pr.probeOne(ctx, rawURL); err != nil {
+			fmt.Fprintf(os.Stderr, "netpool: proxy %s failed health check: %v\n", rawURL, err)
+			pr.pool.Eject(rawURL)
+		}
+	}
+}
+
+func (pr *Prober) probeOne(ctx context.Context, rawURL string) error {
+	proxyURL, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("parsing proxy URL: %w", err)
+	}
+
+	client := &http.Client{
+		Timeout:   pr.timeout,
+		Transport: &http.Transport{Proxy: http.ProxyURL(proxyURL)},
+	}
+
+	reqCtx, cancel := context.WithTimeout(ctx, pr.timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(reqCtx, http.MethodHead, pr.target, nil)
+	if err != nil {
+		return fmt.Errorf("building probe request: %w", err)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 500 {
+		return fmt.Errorf("probe got status %s", resp.Status)
+	}
+	return nil
+}
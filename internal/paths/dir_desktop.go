@@ -0,0 +1,17 @@
+//go:build !android && !ios
+
+package paths
+
+import "os"
+
+// platformConfigDir returns the OS's per-user config directory: e.g.
+// $XDG_CONFIG_HOME or ~/.config on Linux, %AppData% on Windows, or
+// ~/Library/Application Support on macOS.
+func platformConfigDir() (string, error) {
+	return os.UserConfigDir()
+}
+
+// platformCacheDir returns the OS's per-user cache directory.
+func platformCacheDir() (string, error) {
+	return os.UserCacheDir()
+}
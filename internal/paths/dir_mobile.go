@@ -0,0 +1,17 @@
+//go:build android || ios
+
+package paths
+
+import "gioui.org/app"
+
+// platformConfigDir returns Gio's per-app data directory, the only
+// writable location inside the Android/iOS sandbox.
+func platformConfigDir() (string, error) {
+	return app.DataDir()
+}
+
+// platformCacheDir mirrors platformConfigDir: Android/iOS sandboxes don't
+// expose a separate writable cache location through Gio.
+func platformCacheDir() (string, error) {
+	return app.DataDir()
+}
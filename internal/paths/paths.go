@@ -0,0 +1,126 @@
+// Package paths resolves where tix-scraper keeps its persistent data — bot
+// configs and the encrypted user vault — across desktop, Android, and iOS,
+// instead of assuming the current working directory is writable.
+package paths
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// ConfigDirEnv overrides the resolved config directory when ConfigDir's
+// override argument (the -config-dir flag's value) is empty; a non-empty
+// flag value wins over it.
+const ConfigDirEnv = "TIX_CONFIG_DIR"
+
+// appDirName is appended to the OS's per-user config directory on desktop.
+const appDirName = "tix-scraper"
+
+// legacyDataDir is the hardcoded directory this repo used before
+// per-platform config directories existed; its bot config and vault files
+// are migrated into ConfigDir's result (see migrateLegacy).
+const legacyDataDir = "data"
+
+// Filenames stored under ConfigDir's directory.
+const (
+	BotsConfigFile  = "bots_config.json"
+	VaultFile       = "users.vault"
+	LegacyUsersFile = "users.json"
+	TOTPVaultFile   = "totp.vault"
+	SchedulerDBFile = "scheduler.db"
+	ListingIndexDir = "listings.bleve"
+	WatchesFile     = "watches.json"
+)
+
+// ConfigDir resolves, creates (0700), and returns the directory tix-scraper
+// stores bots_config.json and the vault in. override wins if non-empty
+// (the -config-dir flag), then $TIX_CONFIG_DIR, then the OS's per-user
+// config directory (app.DataDir() on Android/iOS, os.UserConfigDir()
+// elsewhere). Leftover bot config and vault files in ./data from before
+// this package existed are copied in (see migrateLegacy).
+func ConfigDir(override string) (string, error) {
+	dir := override
+	if dir == "" {
+		dir = os.Getenv(ConfigDirEnv)
+	}
+	if dir == "" {
+		platform, err := platformConfigDir()
+		if err != nil {
+			return "", fmt.Errorf("paths: resolving config directory: %w", err)
+		}
+		dir = filepath.Join(platform, appDirName)
+	}
+
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", fmt.Errorf("paths: creating %s: %w", dir, err)
+	}
+
+	migrateLegacy(dir)
+	return dir, nil
+}
+
+// CacheDir resolves and creates (0700) the directory for disposable data
+// such as scraper run logs. With no override and no $TIX_CONFIG_DIR it is
+// the OS's per-user cache directory, which on desktop is separate from
+// ConfigDir so clearing a cache can't lose bot configs or the vault. When
+// either is set, CacheDir returns that same directory, as ConfigDir does;
+// on Android/iOS both resolve under app.DataDir().
+func CacheDir(override string) (string, error) {
+	dir := override
+	if dir == "" {
+		dir = os.Getenv(ConfigDirEnv)
+	}
+	if dir == "" {
+		platform, err := platformCacheDir()
+		if err != nil {
+			return "", fmt.Errorf("paths: resolving cache directory: %w", err)
+		}
+		dir = filepath.Join(platform, appDirName)
+	}
+
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", fmt.Errorf("paths: creating %s: %w", dir, err)
+	}
+	return dir, nil
+}
+
+// migrateLegacy copies bots_config.json, users.vault and users.json left
+// over in ./data from before per-platform config directories existed into
+// dir; no other ./data file is copied. It's best-effort and idempotent: a
+// missing legacy directory, or a file already present at the destination,
+// is not an error.
+func migrateLegacy(dir string) {
+	for _, name := range []string{BotsConfigFile, VaultFile, LegacyUsersFile} {
+		dst := filepath.Join(dir, name)
+		if _, err := os.Stat(dst); err == nil {
+			continue
+		}
+
+		src := filepath.Join(legacyDataDir, name)
+		if err := copyFile(src, dst); err != nil && !os.IsNotExist(err) {
+			fmt.Fprintf(os.Stderr, "paths: migrating %s: %v\n", src, err)
+		}
+	}
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	if _, err := io.Copy(out, in); err != nil {
+		os.Remove(dst)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,266 @@
+package scheduler
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule computes successive fire times for a job.
+type Schedule interface {
+	// Next returns the first fire time strictly after from, and false if
+	// the schedule will never fire again (a past @at, or a cron
+	// expression with no satisfiable combination within the search cap).
+	Next(from time.Time) (time.Time, bool)
+}
+
+// maxSearchYears bounds how far into the future Next will look for a
+// cron expression before concluding it can never fire (e.g. "31 2 30 2 *"
+// — Feb 30th doesn't exist).
+const maxSearchYears = 4
+
+// ParseSchedule parses a Job.Cron string into a Schedule. It accepts:
+//
+//   - standard Vixie-cron 5-field "minute hour dom month dow"
+//   - 6-field "second minute hour dom month dow" (see note on seconds below)
+//   - "@every <duration>", parsed with time.ParseDuration
+//   - "@hourly", shorthand for "0 * * * *"
+//   - "@at(<RFC3339 timestamp>)", a one-shot fire at an exact instant
+//
+// Fields support "*", "?" (day-of-week/day-of-month only, same meaning as
+// "*"), "a-b" ranges, "a,b,c" lists, and "*/n" or "a-b/n" steps.
+//
+// Next-fire computation walks minute-by-minute from now+1m, testing each
+// candidate against a bitmask built per field, and gives up after
+// maxSearchYears. Because the search granularity is one minute, a 6-field
+// expression's seconds field is dropped without being parsed or checked:
+// the schedule fires at second 0 of every matching minute, whatever the
+// seconds field says.
+func ParseSchedule(spec string) (Schedule, error) {
+	spec = strings.TrimSpace(spec)
+	switch {
+	case spec == "@hourly":
+		spec = "0 * * * *"
+	case strings.HasPrefix(spec, "@every "):
+		dur, err := time.ParseDuration(strings.TrimPrefix(spec, "@every "))
+		if err != nil {
+			return nil, fmt.Errorf("scheduler: parsing @every duration: %w", err)
+		}
+		return everySchedule{interval: dur}, nil
+	case strings.HasPrefix(spec, "@at(") && strings.HasSuffix(spec, ")"):
+		raw := strings.TrimSuffix(strings.TrimPrefix(spec, "@at("), ")")
+		at, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			return nil, fmt.Errorf("scheduler: parsing @at timestamp: %w", err)
+		}
+		return atSchedule{at: at}, nil
+	}
+
+	return parseCron(spec)
+}
+
+// everySchedule fires at a fixed interval from whatever time Next is
+// called with, i.e. it doesn't anchor to a fixed epoch.
+type everySchedule struct {
+	interval time.Duration
+}
+
+func (e everySchedule) Next(from time.Time) (time.Time, bool) {
+	if e.interval <= 0 {
+		return time.Time{}, false
+	}
+	return from.Add(e.interval), true
+}
+
+// atSchedule fires exactly once.
+type atSchedule struct {
+	at time.Time
+}
+
+func (a atSchedule) Next(from time.Time) (time.Time, bool) {
+	if !a.at.After(from) {
+		return time.Time{}, false
+	}
+	return a.at, true
+}
+
+// cronSchedule is the bitmask form of a parsed 5- or 6-field expression.
+// A 6-field expression's seconds field is not stored; see the doc comment
+// on ParseSchedule.
+type cronSchedule struct {
+	minute uint64 // bits 0-59
+	hour   uint32 // bits 0-23
+	dom    uint32 // bits 1-31
+	month  uint16 // bits 1-12
+	dow    uint8  // bits 0-6, Sunday=0
+
+	domRestricted bool // true if dom field wasn't "*"/"?"
+	dowRestricted bool // true if dow field wasn't "*"/"?"
+}
+
+func parseCron(spec string) (Schedule, error) {
+	fields := strings.Fields(spec)
+	switch len(fields) {
+	case 6:
+		// Drop the leading seconds field; see the ParseSchedule doc
+		// comment on minute-granularity search.
+		fields = fields[1:]
+	case 5:
+		// already minute hour dom month dow
+	default:
+		return nil, fmt.Errorf("scheduler: cron expression %q must have 5 or 6 fields, got %d", spec, len(fields))
+	}
+
+	minute, err := parseField(fields[0], 0, 59)
+	if err != nil {
+		return nil, fmt.Errorf("scheduler: minute field: %w", err)
+	}
+	hour, err := parseField(fields[1], 0, 23)
+	if err != nil {
+		return nil, fmt.Errorf("scheduler: hour field: %w", err)
+	}
+	dom, err := parseField(fields[2], 1, 31)
+	if err != nil {
+		return nil, fmt.Errorf("scheduler: day-of-month field: %w", err)
+	}
+	month, err := parseField(fields[3], 1, 12)
+	if err != nil {
+		return nil, fmt.Errorf("scheduler: month field: %w", err)
+	}
+	dow, err := parseField(fields[4], 0, 6)
+	if err != nil {
+		return nil, fmt.Errorf("scheduler: day-of-week field: %w", err)
+	}
+
+	return &cronSchedule{
+		minute:        minute,
+		hour:          uint32(hour),
+		dom:           uint32(dom),
+		month:         uint16(month),
+		dow:           uint8(dow),
+		domRestricted: !isWildcard(fields[2]),
+		dowRestricted: !isWildcard(fields[4]),
+	}, nil
+}
+
+func isWildcard(field string) bool {
+	return field == "*" || field == "?"
+}
+
+// parseField turns one cron field into a bitmask over [min,max], via a
+// comma-separated list of "*", "?", "n", "a-b", "*/n", or "a-b/n" terms.
+func parseField(field string, min, max int) (uint64, error) {
+	if isWildcard(field) {
+		return fullMask(min, max), nil
+	}
+
+	var mask uint64
+	for _, term := range strings.Split(field, ",") {
+		lo, hi, step, err := parseTerm(term, min, max)
+		if err != nil {
+			return 0, err
+		}
+		for v := lo; v <= hi; v += step {
+			mask |= 1 << uint(v)
+		}
+	}
+	if mask == 0 {
+		return 0, fmt.Errorf("term %q matches nothing in range [%d,%d]", field, min, max)
+	}
+	return mask, nil
+}
+
+func fullMask(min, max int) uint64 {
+	var mask uint64
+	for v := min; v <= max; v++ {
+		mask |= 1 << uint(v)
+	}
+	return mask
+}
+
+// parseTerm parses one comma-delimited piece of a field: "*/n", "a-b/n",
+// "a-b", or a bare "n".
+func parseTerm(term string, min, max int) (lo, hi, step int, err error) {
+	step = 1
+	rangePart := term
+	if idx := strings.IndexByte(term, '/'); idx >= 0 {
+		rangePart = term[:idx]
+		step, err = strconv.Atoi(term[idx+1:])
+		if err != nil || step <= 0 {
+			return 0, 0, 0, fmt.Errorf("invalid step in %q", term)
+		}
+	}
+
+	if isWildcard(rangePart) {
+		return min, max, step, nil
+	}
+
+	if idx := strings.IndexByte(rangePart, '-'); idx >= 0 {
+		lo, err = strconv.Atoi(rangePart[:idx])
+		if err != nil {
+			return 0, 0, 0, fmt.Errorf("invalid range start in %q", term)
+		}
+		hi, err = strconv.Atoi(rangePart[idx+1:])
+		if err != nil {
+			return 0, 0, 0, fmt.Errorf("invalid range end in %q", term)
+		}
+	} else {
+		lo, err = strconv.Atoi(rangePart)
+		if err != nil {
+			return 0, 0, 0, fmt.Errorf("invalid value %q", term)
+		}
+		hi = lo
+	}
+
+	if lo < min || hi > max || lo > hi {
+		return 0, 0, 0, fmt.Errorf("term %q out of range [%d,%d]", term, min, max)
+	}
+	return lo, hi, step, nil
+}
+
+// Next walks minute-by-minute from from+1m, testing each candidate's
+// month/dom/hour/minute/dow against the parsed bitmasks, and gives up
+// after maxSearchYears of searching — an expression like "31 2 30 2 *"
+// (Feb 30th) will never match and Next reports false rather than
+// looping forever.
+func (c *cronSchedule) Next(from time.Time) (time.Time, bool) {
+	candidate := from.Truncate(time.Minute).Add(time.Minute)
+	limit := from.AddDate(maxSearchYears, 0, 0)
+
+	for candidate.Before(limit) {
+		if c.matches(candidate) {
+			return candidate, true
+		}
+		candidate = candidate.Add(time.Minute)
+	}
+	return time.Time{}, false
+}
+
+func (c *cronSchedule) matches(t time.Time) bool {
+	if c.month&(1<<uint(t.Month())) == 0 {
+		return false
+	}
+	if c.hour&(1<<uint(t.Hour())) == 0 {
+		return false
+	}
+	if c.minute&(1<<uint(t.Minute())) == 0 {
+		return false
+	}
+
+	domMatch := c.dom&(1<<uint(t.Day())) != 0
+	dowMatch := c.dow&(1<<uint(t.Weekday())) != 0
+
+	switch {
+	case c.domRestricted && c.dowRestricted:
+		// Vixie-cron quirk: when both are restricted, a day matching
+		// EITHER is enough.
+		return domMatch || dowMatch
+	case c.domRestricted:
+		return domMatch
+	case c.dowRestricted:
+		return dowMatch
+	default:
+		return true
+	}
+}
@@ -0,0 +1,44 @@
+package scheduler
+
+import "time"
+
+// EventKind categorizes an Event for subscribers that only care about
+// certain transitions (the Prometheus exporter counts all four kinds and
+// sums CaptchaSolved durations). The GUI's Jobs panel does not read
+// events; it polls Scheduler.Status.
+type EventKind int
+
+const (
+	EventFired EventKind = iota
+	EventSucceeded
+	EventFailed
+	EventCaptchaSolved
+)
+
+func (k EventKind) String() string {
+	switch k {
+	case EventFired:
+		return "fired"
+	case EventSucceeded:
+		return "succeeded"
+	case EventFailed:
+		return "failed"
+	case EventCaptchaSolved:
+		return "captcha_solved"
+	default:
+		return "unknown"
+	}
+}
+
+// Event is emitted on Scheduler.Events() for every run transition.
+type Event struct {
+	JobID string
+	Site  string
+	Kind  EventKind
+	Time  time.Time
+
+	// Duration is set on EventSucceeded/EventFailed: how long the run took.
+	Duration time.Duration
+	// Err is set on EventFailed.
+	Err error
+}
@@ -0,0 +1,44 @@
+// Package scheduler dispatches scrape runs on cron-like schedules,
+// checking browser contexts out of a chromedp pool per job and reporting
+// structured events for the GUI and an optional Prometheus exporter. See
+// cron.go for schedule parsing, store.go for the last-fire persistence
+// that drives missed-run catch-up, and scheduler.go for the dispatch loop
+// itself.
+package scheduler
+
+import "time"
+
+// Job describes one scheduled scrape target.
+type Job struct {
+	ID            string
+	Site          string // site tag, passed to the browser pool for proxy/session affinity
+	URL           string
+	Cron          string         // see ParseSchedule for the accepted syntax
+	MaxConcurrent int            // in-flight runs of this job allowed at once; <=1 means no overlap
+	JitterMS      int            // random delay in [0, JitterMS) added before each fire, to avoid every job in a batch hitting the same second
+	Deadline      time.Time      // zero means no deadline; once passed, the job stops firing
+	Payload       map[string]any // opaque data handed to the Handler, e.g. event/ticket IDs
+}
+
+// CatchUpPolicy controls what happens to fires that were missed while the
+// process wasn't running (crash, restart, laptop closed).
+type CatchUpPolicy int
+
+const (
+	// SkipMissed ignores anything missed and resumes from the next
+	// regular fire after now.
+	SkipMissed CatchUpPolicy = iota
+	// RunOnce fires the job once immediately if any fire was missed,
+	// then resumes the regular schedule.
+	RunOnce
+	// RunAll fires once for every missed occurrence, in order, before
+	// resuming the regular schedule. Bounded by maxCatchUpRuns so a long
+	// downtime on a tight schedule can't queue an unbounded backlog.
+	RunAll
+)
+
+// maxCatchUpRuns caps how many missed occurrences RunAll will replay.
+// Anything beyond this is dropped without being run or logged, the same
+// way ParseSchedule's 4-year search cap protects against a schedule that
+// can never fire.
+const maxCatchUpRuns = 1000
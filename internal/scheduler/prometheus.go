@@ -0,0 +1,106 @@
+package scheduler
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+)
+
+// siteStats accumulates counters for one site, keyed by Event.Site.
+type siteStats struct {
+	attempts        int64
+	successes       int64
+	failures        int64
+	captchaSolves   int64
+	captchaSolveSum float64 // seconds
+}
+
+// PrometheusExporter subscribes to a Scheduler's event stream and exposes
+// per-site throughput, failure rate, and CAPTCHA-solve latency as a
+// Prometheus text-exposition endpoint. It's hand-rolled rather than built
+// on client_golang, since this repo doesn't otherwise depend on the
+// Prometheus client library and the exposition format needed here is a
+// handful of counters and gauges.
+type PrometheusExporter struct {
+	mu    sync.Mutex
+	sites map[string]*siteStats
+}
+
+// NewPrometheusExporter creates an exporter and starts consuming sched's
+// event stream in a background goroutine for the lifetime of the process
+// (there's no Close: it simply stops once the channel closes, which never
+// happens during normal operation).
+func NewPrometheusExporter(sched *Scheduler) *PrometheusExporter {
+	e := &PrometheusExporter{sites: make(map[string]*siteStats)}
+	go e.consume(sched.Events())
+	return e
+}
+
+func (e *PrometheusExporter) consume(events <-chan Event) {
+	for ev := range events {
+		e.mu.Lock()
+		stats, ok := e.sites[ev.Site]
+		if !ok {
+			stats = &siteStats{}
+			e.sites[ev.Site] = stats
+		}
+		switch ev.Kind {
+		case EventFired:
+			stats.attempts++
+		case EventSucceeded:
+			stats.successes++
+		case EventFailed:
+			stats.failures++
+		case EventCaptchaSolved:
+			stats.captchaSolves++
+			stats.captchaSolveSum += ev.Duration.Seconds()
+		}
+		e.mu.Unlock()
+	}
+}
+
+// ServeHTTP writes the current counters in Prometheus text exposition
+// format. Mount it directly, e.g. http.Handle("/metrics", exporter).
+func (e *PrometheusExporter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+
+	sites := make([]string, 0, len(e.sites))
+	for site := range e.sites {
+		sites = append(sites, site)
+	}
+	sort.Strings(sites)
+
+	fmt.Fprintln(w, "# HELP tix_scheduler_attempts_total Scrape attempts dispatched, per site.")
+	fmt.Fprintln(w, "# TYPE tix_scheduler_attempts_total counter")
+	for _, site := range sites {
+		fmt.Fprintf(w, "tix_scheduler_attempts_total{site=%q} %d\n", site, e.sites[site].attempts)
+	}
+
+	fmt.Fprintln(w, "# HELP tix_scheduler_successes_total Scrape attempts that succeeded, per site.")
+	fmt.Fprintln(w, "# TYPE tix_scheduler_successes_total counter")
+	for _, site := range sites {
+		fmt.Fprintf(w, "tix_scheduler_successes_total{site=%q} %d\n", site, e.sites[site].successes)
+	}
+
+	fmt.Fprintln(w, "# HELP tix_scheduler_failures_total Scrape attempts that failed, per site.")
+	fmt.Fprintln(w, "# TYPE tix_scheduler_failures_total counter")
+	for _, site := range sites {
+		fmt.Fprintf(w, "tix_scheduler_failures_total{site=%q} %d\n", site, e.sites[site].failures)
+	}
+
+	fmt.Fprintln(w, "# HELP tix_scheduler_captcha_solve_seconds_sum Total CAPTCHA-solve time, per site.")
+	fmt.Fprintln(w, "# TYPE tix_scheduler_captcha_solve_seconds_sum counter")
+	for _, site := range sites {
+		fmt.Fprintf(w, "tix_scheduler_captcha_solve_seconds_sum{site=%q} %f\n", site, e.sites[site].captchaSolveSum)
+	}
+
+	fmt.Fprintln(w, "# HELP tix_scheduler_captcha_solves_total CAPTCHAs solved, per site.")
+	fmt.Fprintln(w, "# TYPE tix_scheduler_captcha_solves_total counter")
+	for _, site := range sites {
+		fmt.Fprintf(w, "tix_scheduler_captcha_solves_total{site=%q} %d\n", site, e.sites[site].captchaSolves)
+	}
+}
@@ -0,0 +1,407 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/chromedp/chromedp"
+
+	"tix-scraper/internal/netpool"
+)
+
+// Handler runs one fire of a job. ctx is a chromedp browser context already
+// checked out from the Scheduler's Pool (if one is configured) using the
+// job's Site as the pool's siteTag; Handler should use it directly with
+// chromedp actions rather than building its own allocator.
+type Handler func(ctx context.Context, job Job) error
+
+// JobStatus is a point-in-time snapshot of one job, for the GUI panel.
+type JobStatus struct {
+	Job      Job
+	NextFire time.Time
+	LastFire time.Time
+	LastErr  error
+	Running  bool
+}
+
+type scheduledJob struct {
+	job      Job
+	schedule Schedule
+	next     time.Time
+	sem      chan struct{} // buffered to MaxConcurrent, acts as a counting lock
+
+	catchUpQueue []time.Time // pending RunAll fire times, oldest first
+
+	mu      sync.Mutex
+	lastRun time.Time
+	lastErr error
+	running int
+}
+
+// Scheduler dispatches Jobs onto Handler according to their parsed
+// Schedule, persisting last-fire times to Store so a restart can catch up
+// per CatchUpPolicy, and optionally checking a chromedp browser context
+// out of Pool per fire.
+type Scheduler struct {
+	Store   Store
+	Pool    *netpool.Pool // optional; nil means Handler's browser context uses no proxy
+	Handler Handler
+	CatchUp CatchUpPolicy
+
+	mu     sync.Mutex
+	jobs   map[string]*scheduledJob
+	events chan Event
+}
+
+// NewScheduler returns a Scheduler ready for AddJob/Start. events is
+// buffered so that a slow or absent subscriber (no GUI panel open, no
+// Prometheus exporter wired up) doesn't block dispatch; events are
+// dropped once the buffer is full rather than backing up the scheduler.
+func NewScheduler(store Store, handler Handler, catchUp CatchUpPolicy) *Scheduler {
+	return &Scheduler{
+		Store:   store,
+		Handler: handler,
+		CatchUp: catchUp,
+		jobs:    make(map[string]*scheduledJob),
+		events:  make(chan Event, 256),
+	}
+}
+
+// Events returns the event channel the Prometheus exporter reads from (the
+// GUI's Jobs panel polls Status instead).
+func (s *Scheduler) Events() <-chan Event {
+	return s.events
+}
+
+func (s *Scheduler) emit(e Event) {
+	select {
+	case s.events <- e:
+	default:
+		fmt.Fprintf(os.Stderr, "scheduler: event channel full, dropping %s event for job %s\n", e.Kind, e.JobID)
+	}
+}
+
+// ReportCaptchaSolved lets a Handler (which typically drives a
+// captcha.Driver internally) report solve latency back through the same
+// event stream the Prometheus exporter and GUI already watch, without
+// Scheduler importing the captcha package.
+func (s *Scheduler) ReportCaptchaSolved(jobID, site string, d time.Duration) {
+	s.emit(Event{JobID: jobID, Site: site, Kind: EventCaptchaSolved, Time: time.Now(), Duration: d})
+}
+
+// AddJob parses job.Cron, applies CatchUp against the persisted last-fire
+// time (if any), and registers the job for dispatch by Start.
+func (s *Scheduler) AddJob(job Job) error {
+	schedule, err := ParseSchedule(job.Cron)
+	if err != nil {
+		return fmt.Errorf("scheduler: adding job %s: %w", job.ID, err)
+	}
+
+	concurrency := job.MaxConcurrent
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+
+	sj := &scheduledJob{
+		job:      job,
+		schedule: schedule,
+		sem:      make(chan struct{}, concurrency),
+	}
+
+	now := time.Now()
+	sj.next = s.resolveNext(sj, now)
+
+	s.mu.Lock()
+	s.jobs[job.ID] = sj
+	s.mu.Unlock()
+	return nil
+}
+
+// resolveNext applies CatchUp: it consults the persisted last-fire time
+// for sj.job.ID and, depending on the policy, may schedule an immediate
+// catch-up fire instead of the next regular one.
+func (s *Scheduler) resolveNext(sj *scheduledJob, now time.Time) time.Time {
+	if s.Store == nil || s.CatchUp == SkipMissed {
+		next, ok := sj.schedule.Next(now)
+		if !ok {
+			return time.Time{}
+		}
+		return next
+	}
+
+	lastFire, ok, err := s.Store.LastFire(sj.job.ID)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "scheduler: reading last-fire for %s: %v\n", sj.job.ID, err)
+	}
+	if !ok {
+		next, ok := sj.schedule.Next(now)
+		if !ok {
+			return time.Time{}
+		}
+		return next
+	}
+
+	missed := s.countMissed(sj.schedule, lastFire, now)
+	if missed == 0 {
+		next, ok := sj.schedule.Next(now)
+		if !ok {
+			return time.Time{}
+		}
+		return next
+	}
+
+	switch s.CatchUp {
+	case RunOnce:
+		return now
+	case RunAll:
+		sj.catchUpQueue = s.missedFireTimes(sj.schedule, lastFire, now)
+		// Pop the first fire so advance doesn't replay it; sj isn't
+		// registered yet, so no lock is needed.
+		first, _ := sj.popCatchUp()
+		return first
+	default:
+		next, ok := sj.schedule.Next(now)
+		if !ok {
+			return time.Time{}
+		}
+		return next
+	}
+}
+
+// countMissed reports how many times schedule would have fired strictly
+// between lastFire and now, capped at maxCatchUpRuns+1 so a long-idle
+// tight schedule doesn't spend unbounded time counting.
+func (s *Scheduler) countMissed(schedule Schedule, lastFire, now time.Time) int {
+	count := 0
+	cursor := lastFire
+	for count <= maxCatchUpRuns {
+		next, ok := schedule.Next(cursor)
+		if !ok || !next.Before(now) {
+			break
+		}
+		count++
+		cursor = next
+	}
+	return count
+}
+
+// missedFireTimes returns every fire time schedule would have produced
+// strictly between lastFire and now, bounded by maxCatchUpRuns.
+func (s *Scheduler) missedFireTimes(schedule Schedule, lastFire, now time.Time) []time.Time {
+	var times []time.Time
+	cursor := lastFire
+	for len(times) < maxCatchUpRuns {
+		next, ok := schedule.Next(cursor)
+		if !ok || !next.Before(now) {
+			break
+		}
+		times = append(times, next)
+		cursor = next
+	}
+	if len(times) == 0 {
+		// Only reachable if countMissed found >0 but the walk above
+		// found none, which shouldn't happen; fire once now rather than
+		// return an empty queue Start would choke on.
+		times = append(times, now)
+	}
+	return times
+}
+
+// Start runs the dispatch loop until ctx is canceled. It checks every
+// registered job once a second; cron's coarsest useful resolution is a
+// minute, so a 1s poll is cheap and still catches RunAll catch-up queues
+// (which fire back-to-back rather than waiting for their original cron
+// slot) promptly.
+func (s *Scheduler) Start(ctx context.Context) {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			s.tick(ctx, now)
+		}
+	}
+}
+
+func (s *Scheduler) tick(ctx context.Context, now time.Time) {
+	s.mu.Lock()
+	due := make([]*scheduledJob, 0)
+	for _, sj := range s.jobs {
+		if !sj.job.Deadline.IsZero() && now.After(sj.job.Deadline) {
+			continue
+		}
+		if !sj.next.IsZero() && !sj.next.After(now) {
+			due = append(due, sj)
+		}
+	}
+	s.mu.Unlock()
+
+	for _, sj := range due {
+		s.advance(sj, now)
+		s.dispatch(ctx, sj)
+	}
+}
+
+// popCatchUp pops the next queued catch-up fire time off sj.catchUpQueue,
+// if any. Callers must hold sj.mu.
+func (sj *scheduledJob) popCatchUp() (time.Time, bool) {
+	if len(sj.catchUpQueue) == 0 {
+		return time.Time{}, false
+	}
+	t := sj.catchUpQueue[0]
+	sj.catchUpQueue = sj.catchUpQueue[1:]
+	return t, true
+}
+
+// advance sets sj.next to the job's next fire: the next queued catch-up
+// time if RunAll left one pending, otherwise the next regular schedule
+// fire after now. sj.next and sj.catchUpQueue are also read from Status
+// (the GUI goroutine), so both are guarded by sj.mu here rather than left
+// to tick's single-goroutine caller alone.
+func (s *Scheduler) advance(sj *scheduledJob, now time.Time) {
+	sj.mu.Lock()
+	defer sj.mu.Unlock()
+
+	if t, ok := sj.popCatchUp(); ok {
+		sj.next = t
+		return
+	}
+	next, ok := sj.schedule.Next(now)
+	if !ok {
+		sj.next = time.Time{}
+		return
+	}
+	sj.next = next
+}
+
+// dispatch runs one fire of sj in its own goroutine: it waits for a
+// concurrency slot, applies jitter, checks out a browser context (via
+// Pool if configured), runs Handler, persists the fire time, and emits
+// events throughout.
+func (s *Scheduler) dispatch(ctx context.Context, sj *scheduledJob) {
+	select {
+	case sj.sem <- struct{}{}:
+	default:
+		fmt.Fprintf(os.Stderr, "scheduler: job %s at MaxConcurrent, skipping this fire\n", sj.job.ID)
+		return
+	}
+
+	go func() {
+		defer func() { <-sj.sem }()
+
+		if sj.job.JitterMS > 0 {
+			delay := time.Duration(rand.Intn(sj.job.JitterMS)) * time.Millisecond
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(delay):
+			}
+		}
+
+		sj.mu.Lock()
+		sj.running++
+		sj.mu.Unlock()
+
+		start := time.Now()
+		s.emit(Event{JobID: sj.job.ID, Site: sj.job.Site, Kind: EventFired, Time: start})
+
+		runCtx, cancel := s.browserContext(ctx, sj.job.Site)
+		err := s.Handler(runCtx, sj.job)
+		cancel()
+
+		duration := time.Since(start)
+		sj.mu.Lock()
+		sj.running--
+		sj.lastRun = start
+		sj.lastErr = err
+		sj.mu.Unlock()
+
+		if s.Store != nil && err == nil {
+			if serr := s.Store.SetLastFire(sj.job.ID, start); serr != nil {
+				fmt.Fprintf(os.Stderr, "scheduler: persisting last-fire for %s: %v\n", sj.job.ID, serr)
+			}
+		}
+
+		if err != nil {
+			s.emit(Event{JobID: sj.job.ID, Site: sj.job.Site, Kind: EventFailed, Time: time.Now(), Duration: duration, Err: err})
+		} else {
+			s.emit(Event{JobID: sj.job.ID, Site: sj.job.Site, Kind: EventSucceeded, Time: time.Now(), Duration: duration})
+		}
+	}()
+}
+
+// browserContext builds a chromedp context for one fire. If Pool is set,
+// it leases a proxy tagged with site and reports the outcome back to the
+// pool once the run finishes (the returned cancel func does this before
+// tearing the context down).
+func (s *Scheduler) browserContext(parent context.Context, site string) (context.Context, context.CancelFunc) {
+	opts := chromedp.DefaultExecAllocatorOptions[:]
+
+	var lease *netpool.Lease
+	if s.Pool != nil {
+		var opt chromedp.ExecAllocatorOption
+		opt, lease = netpool.WithPoolLease(s.Pool, site)
+		opts = append(opts, opt)
+	}
+
+	allocCtx, allocCancel := chromedp.NewExecAllocator(parent, opts...)
+	browserCtx, browserCancel := chromedp.NewContext(allocCtx)
+
+	cancel := func() {
+		// Read the outcome before cancelling: afterwards browserCtx.Err()
+		// is always non-nil.
+		outcome := netpool.OutcomeSuccess
+		if browserCtx.Err() != nil {
+			outcome = netpool.OutcomeTimeout
+		}
+		browserCancel()
+		allocCancel()
+		if lease != nil {
+			lease.Report(outcome)
+		}
+	}
+	return browserCtx, cancel
+}
+
+// RunNow triggers jobID immediately, outside its regular schedule, still
+// subject to its MaxConcurrent limit.
+func (s *Scheduler) RunNow(ctx context.Context, jobID string) error {
+	s.mu.Lock()
+	sj, ok := s.jobs[jobID]
+	s.mu.Unlock()
+	if !ok {
+		return fmt.Errorf("scheduler: no job registered with ID %q", jobID)
+	}
+
+	s.dispatch(ctx, sj)
+	return nil
+}
+
+// Status returns a snapshot of every registered job, sorted by nothing in
+// particular — callers that want a stable order (the GUI) should sort by
+// Job.ID themselves.
+func (s *Scheduler) Status() []JobStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	statuses := make([]JobStatus, 0, len(s.jobs))
+	for _, sj := range s.jobs {
+		sj.mu.Lock()
+		statuses = append(statuses, JobStatus{
+			Job:      sj.job,
+			NextFire: sj.next,
+			LastFire: sj.lastRun,
+			LastErr:  sj.lastErr,
+			Running:  sj.running > 0,
+		})
+		sj.mu.Unlock()
+	}
+	return statuses
+}
@@ -0,0 +1,74 @@
+package scheduler
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/tidwall/buntdb"
+)
+
+// Store persists the last successful fire time per job, so a restart can
+// tell how many (if any) fires were missed and apply the configured
+// CatchUpPolicy.
+type Store interface {
+	LastFire(jobID string) (t time.Time, ok bool, err error)
+	SetLastFire(jobID string, t time.Time) error
+	Close() error
+}
+
+// BuntStore is a Store backed by a buntdb file, keyed by job ID.
+type BuntStore struct {
+	db *buntdb.DB
+}
+
+// OpenBuntStore opens (creating if necessary) a buntdb file at path.
+func OpenBuntStore(path string) (*BuntStore, error) {
+	db, err := buntdb.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("scheduler: opening buntdb store at %s: %w", path, err)
+	}
+	return &BuntStore{db: db}, nil
+}
+
+func (s *BuntStore) LastFire(jobID string) (time.Time, bool, error) {
+	var raw string
+	err := s.db.View(func(tx *buntdb.Tx) error {
+		v, err := tx.Get(lastFireKey(jobID))
+		if err != nil {
+			return err
+		}
+		raw = v
+		return nil
+	})
+	if err == buntdb.ErrNotFound {
+		return time.Time{}, false, nil
+	}
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("scheduler: reading last-fire for %s: %w", jobID, err)
+	}
+
+	t, err := time.Parse(time.RFC3339Nano, raw)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("scheduler: parsing stored last-fire for %s: %w", jobID, err)
+	}
+	return t, true, nil
+}
+
+func (s *BuntStore) SetLastFire(jobID string, t time.Time) error {
+	err := s.db.Update(func(tx *buntdb.Tx) error {
+		_, _, err := tx.Set(lastFireKey(jobID), t.Format(time.RFC3339Nano), nil)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("scheduler: writing last-fire for %s: %w", jobID, err)
+	}
+	return nil
+}
+
+func (s *BuntStore) Close() error {
+	return s.db.Close()
+}
+
+func lastFireKey(jobID string) string {
+	return "lastfire:" + jobID
+}
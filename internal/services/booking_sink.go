@@ -0,0 +1,337 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// BookingSink receives every successfully completed Booking, letting a
+// caller fan reservations out to Discord/Slack/Home Assistant or a
+// downstream inventory DB instead of reading data/bookings.json by hand.
+// Implementations must be safe to call concurrently.
+type BookingSink interface {
+	Publish(ctx context.Context, booking Booking) error
+}
+
+// publishBooking fans booking out to every sink in cfg.Sinks, logging
+// (rather than failing the run) any sink that errors — a single bad
+// webhook shouldn't stop ticket reservations.
+func publishBooking(ctx context.Context, cfg ScraperConfig, booking Booking) {
+	for _, sink := range cfg.Sinks {
+		if sink == nil {
+			continue
+		}
+		if err := sink.Publish(ctx, booking); err != nil {
+			LogToFile("❌ Booking sink error: %v", err)
+		}
+	}
+}
+
+// JSONFileSink is the original data/bookings.json behavior (previously
+// safeSaveBooking/saveBooking) as a BookingSink: every Publish reads the
+// existing array, appends, and writes back via a temp-file-then-rename.
+type JSONFileSink struct {
+	Path string // defaults to "data/bookings.json" if empty
+
+	mu sync.Mutex
+}
+
+func (s *JSONFileSink) path() string {
+	if s.Path != "" {
+		return s.Path
+	}
+	return filepath.Join("data", "bookings.json")
+}
+
+func (s *JSONFileSink) Publish(ctx context.Context, booking Booking) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	path := s.path()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("bookingsink: creating %s: %w", filepath.Dir(path), err)
+	}
+
+	var bookings []Booking
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("bookingsink: reading %s: %w", path, err)
+	}
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &bookings); err != nil {
+			// Start fresh if the file is corrupted, same as the original
+			// safeSaveBooking behavior.
+			bookings = nil
+		}
+	}
+	bookings = append(bookings, booking)
+
+	updated, err := json.MarshalIndent(bookings, "", "  ")
+	if err != nil {
+		return fmt.Errorf("bookingsink: marshaling bookings: %w", err)
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, updated, 0644); err != nil {
+		return fmt.Errorf("bookingsink: writing temp file: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("bookingsink: renaming temp file: %w", err)
+	}
+
+	LogToFile("✅ Booking saved to file. Order: %s", booking.OrderNumber)
+	return nil
+}
+
+// NDJSONSink appends one JSON object per line to Path, for operators who
+// want to tail -f or stream bookings into another process rather than
+// re-parsing the whole bookings.json array on every write.
+type NDJSONSink struct {
+	Path string
+
+	mu sync.Mutex
+}
+
+func (s *NDJSONSink) Publish(ctx context.Context, booking Booking) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(s.Path), 0755); err != nil {
+		return fmt.Errorf("bookingsink: creating %s: %w", filepath.Dir(s.Path), err)
+	}
+
+	f, err := os.OpenFile(s.Path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("bookingsink: opening %s: %w", s.Path, err)
+	}
+	defer f.Close()
+
+	line, err := json.Marshal(booking)
+	if err != nil {
+		return fmt.Errorf("bookingsink: marshaling booking: %w", err)
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("bookingsink: writing %s: %w", s.Path, err)
+	}
+	return nil
+}
+
+// WebhookSink POSTs each booking as JSON to URL, signing the body with
+// HMAC-SHA256 over Secret in the X-Tix-Signature header (hex-encoded,
+// "sha256=" prefixed like GitHub/Stripe webhooks) so the receiver can
+// verify the request actually came from this scraper. A blank Secret
+// sends the request unsigned.
+type WebhookSink struct {
+	URL    string
+	Secret string
+	Client *http.Client
+}
+
+func (s *WebhookSink) Publish(ctx context.Context, booking Booking) error {
+	body, err := json.Marshal(booking)
+	if err != nil {
+		return fmt.Errorf("bookingsink: marshaling booking: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("bookingsink: building webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if s.Secret != "" {
+		mac := hmac.New(sha256.New, []byte(s.Secret))
+		mac.Write(body)
+		req.Header.Set("X-Tix-Signature", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	}
+
+	client := s.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("bookingsink: posting to %s: %w", s.URL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("bookingsink: webhook %s returned status %d", s.URL, resp.StatusCode)
+	}
+	return nil
+}
+
+// SQLSink writes each booking as a row via database/sql, so it works
+// against SQLite, Postgres, or anything else with a registered driver —
+// this package intentionally doesn't import a specific driver; the caller
+// opens DB with whatever one they've already imported for side effects
+// (e.g. mattn/go-sqlite3, lib/pq).
+type SQLSink struct {
+	DB    *sql.DB
+	Table string // defaults to "bookings" if empty
+
+	// Dialect selects placeholder syntax: "" or "sqlite" uses "?", while
+	// "postgres" uses "$1", "$2", ... since database/sql doesn't normalize
+	// that across drivers.
+	Dialect string
+
+	initOnce sync.Once
+	initErr  error
+}
+
+func (s *SQLSink) table() string {
+	if s.Table == "" {
+		return "bookings"
+	}
+	return s.Table
+}
+
+func (s *SQLSink) placeholders(n int) []string {
+	ph := make([]string, n)
+	for i := range ph {
+		if s.Dialect == "postgres" {
+			ph[i] = fmt.Sprintf("$%d", i+1)
+		} else {
+			ph[i] = "?"
+		}
+	}
+	return ph
+}
+
+func (s *SQLSink) ensureTable(ctx context.Context) error {
+	s.initOnce.Do(func() {
+		_, s.initErr = s.DB.ExecContext(ctx, fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+			session_id TEXT, seat TEXT, event_id TEXT, ticket_id TEXT,
+			num_of_tickets TEXT, order_number TEXT, event_name TEXT,
+			event_date TEXT, event_venue TEXT, section TEXT, seat_info TEXT,
+			ticket_info TEXT, ticket_qty TEXT, service_fee TEXT, total TEXT,
+			username TEXT
+		)`, s.table()))
+	})
+	return s.initErr
+}
+
+func (s *SQLSink) Publish(ctx context.Context, booking Booking) error {
+	if err := s.ensureTable(ctx); err != nil {
+		return fmt.Errorf("bookingsink: creating %s table: %w", s.table(), err)
+	}
+
+	columns := "session_id, seat, event_id, ticket_id, num_of_tickets, order_number, " +
+		"event_name, event_date, event_venue, section, seat_info, ticket_info, " +
+		"ticket_qty, service_fee, total, username"
+	stmt := fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)",
+		s.table(), columns, strings.Join(s.placeholders(16), ", "))
+
+	_, err := s.DB.ExecContext(ctx, stmt,
+		booking.SessionID, booking.Seat, booking.EventID, booking.TicketID,
+		booking.NumOfTickets, booking.OrderNumber, booking.EventName,
+		booking.EventDate, booking.EventVenue, booking.Section, booking.SeatInfo,
+		booking.TicketInfo, booking.TicketQty, booking.ServiceFee, booking.Total,
+		booking.UserName)
+	if err != nil {
+		return fmt.Errorf("bookingsink: inserting booking: %w", err)
+	}
+	return nil
+}
+
+// RetryingSink wraps another BookingSink with bounded retries and doubling
+// backoff; if every attempt fails, the booking (and the final error) is
+// appended to DeadLetterPath as NDJSON instead of being lost, so an
+// operator can replay a failed webhook or DB outage later.
+type RetryingSink struct {
+	Sink BookingSink
+
+	MaxAttempts    int           // defaults to 3
+	BaseBackoff    time.Duration // defaults to 1s, doubles every retry
+	DeadLetterPath string        // defaults to "data/bookings_dead_letter.ndjson"
+
+	mu sync.Mutex
+}
+
+func (s *RetryingSink) deadLetterPath() string {
+	if s.DeadLetterPath != "" {
+		return s.DeadLetterPath
+	}
+	return filepath.Join("data", "bookings_dead_letter.ndjson")
+}
+
+func (s *RetryingSink) Publish(ctx context.Context, booking Booking) error {
+	attempts := s.MaxAttempts
+	if attempts <= 0 {
+		attempts = 3
+	}
+	backoff := s.BaseBackoff
+	if backoff <= 0 {
+		backoff = time.Second
+	}
+
+	var lastErr error
+retryLoop:
+	for attempt := 1; attempt <= attempts; attempt++ {
+		lastErr = s.Sink.Publish(ctx, booking)
+		if lastErr == nil {
+			return nil
+		}
+		LogToFile("❌ Booking sink attempt %d/%d failed: %v", attempt, attempts, lastErr)
+
+		if attempt == attempts {
+			break
+		}
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			lastErr = ctx.Err()
+			break retryLoop
+		case <-timer.C:
+		}
+		backoff *= 2
+	}
+
+	if err := s.deadLetter(booking, lastErr); err != nil {
+		LogToFile("❌ Failed to dead-letter booking after exhausting retries: %v", err)
+	}
+	return fmt.Errorf("bookingsink: giving up after %d attempts: %w", attempts, lastErr)
+}
+
+func (s *RetryingSink) deadLetter(booking Booking, cause error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	path := s.deadLetterPath()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("bookingsink: creating %s: %w", filepath.Dir(path), err)
+	}
+
+	entry := struct {
+		Booking Booking   `json:"booking"`
+		Error   string    `json:"error"`
+		Time    time.Time `json:"time"`
+	}{Booking: booking, Error: cause.Error(), Time: time.Now()}
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("bookingsink: marshaling dead letter entry: %w", err)
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("bookingsink: opening %s: %w", path, err)
+	}
+	defer f.Close()
+
+	_, err = f.Write(append(line, '\n'))
+	return err
+}
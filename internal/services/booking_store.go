@@ -0,0 +1,44 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+)
+
+// CaptchaAttempt is one solve attempt against a captcha image, recorded so
+// the resulting dataset (image hash, what the solver read, whether it was
+// accepted, how long it took, which solver) can later be exported to build
+// training data for a local captcha model instead of just logging pass/fail.
+type CaptchaAttempt struct {
+	ImageHash string // sha256 of the PNG bytes captureCaptchaImage produced
+	OCRText   string
+	Success   bool
+	LatencyMS int64
+	Solver    string // the concrete CaptchaSolver's type name
+	Time      time.Time
+}
+
+// BookingStore is the persistence layer behind cfg.Store: a richer
+// alternative to plain JSONFileSink/NDJSONSink that also captures captcha
+// attempts, and supports lookups a flat file can't (GetByOrder) without
+// the caller re-parsing the whole history. SQLiteBookingStore (see
+// sqlite_store.go, built with `-tags sqlite`) is the only implementation
+// today; a nil BookingStore field on ScraperConfig just means captcha
+// attempts aren't recorded and bookings still go through cfg.Sinks as
+// before.
+type BookingStore interface {
+	Insert(ctx context.Context, booking Booking) error
+	List(ctx context.Context) ([]Booking, error)
+	GetByOrder(ctx context.Context, orderNumber string) (Booking, error)
+	RecordCaptchaAttempt(ctx context.Context, attempt CaptchaAttempt) error
+}
+
+// captchaImageHash hashes the raw PNG bytes so two attempts against the
+// same rendered captcha get the same hash even if solved by different
+// solvers; each attempt is still recorded as its own row.
+func captchaImageHash(imgPNG []byte) string {
+	sum := sha256.Sum256(imgPNG)
+	return hex.EncodeToString(sum[:])
+}
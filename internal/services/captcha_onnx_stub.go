@@ -0,0 +1,25 @@
+//go:build !onnx
+
+package services
+
+import (
+	"context"
+	"fmt"
+)
+
+// ONNXSolver is the no-tag stand-in for captcha_onnx.go's real
+// implementation, so ScraperConfig.CaptchaSolvers and config-driven solver
+// selection can reference ONNXSolver unconditionally. Build with
+// `-tags onnx` (and github.com/yalue/onnxruntime_go, plus the onnxruntime
+// shared library — neither is a dependency of this module by default) to
+// load and run the model (that build's Solve still returns an error, as it
+// does not decode the model's output); here Solve just reports that the
+// tag is missing.
+type ONNXSolver struct {
+	ModelPath string
+	Alphabet  string
+}
+
+func (s *ONNXSolver) Solve(ctx context.Context, imgPNG []byte) (string, float64, error) {
+	return "", 0, fmt.Errorf("captchasolver: ONNXSolver requires building with -tags onnx")
+}
@@ -0,0 +1,419 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"image/png"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"tix-scraper/internal/captcha"
+)
+
+// CaptchaSolver recognizes text in a PNG-encoded CAPTCHA image, reporting a
+// confidence in [0,1]. Implementations may call out to local OCR, a
+// Tesseract wrapper, or a paid 3rd-party solving service. This is the
+// services-package, raw-bytes counterpart to captcha.Solver (which works
+// on a decoded image.Image as part of the captcha package's cleanup
+// pipeline); ImageSolverAdapter bridges the two so captcha's solvers can
+// be reused here without duplicating them.
+type CaptchaSolver interface {
+	Solve(ctx context.Context, imgPNG []byte) (text string, confidence float64, err error)
+}
+
+// CaptchaStrategy selects how ScraperConfig.CaptchaSolvers are combined
+// when more than one is configured.
+type CaptchaStrategy int
+
+const (
+	// FirstSuccess tries each solver in order and returns the first one
+	// that succeeds (nil error), regardless of confidence. This is the
+	// default.
+	FirstSuccess CaptchaStrategy = iota
+	// MajorityVote runs every solver and returns the text most of them
+	// agreed on, breaking ties in solver order.
+	MajorityVote
+	// ConfidenceThreshold runs each solver in order and returns the first
+	// whose confidence meets ScraperConfig.CaptchaMinConfidence, falling
+	// back to the highest-confidence result seen if none do.
+	ConfidenceThreshold
+	// ParallelBestConfidence queries every solver concurrently (instead of
+	// in order, like the other strategies) and returns whichever
+	// succeeding result reports the highest confidence. Useful for cutting
+	// latency during a ticket drop when solvers have comparable accuracy
+	// but very different response times (e.g. a local model vs. an HTTP
+	// API).
+	ParallelBestConfidence
+)
+
+// ImageSolverAdapter decodes imgPNG and delegates to an existing
+// captcha.Solver (e.g. captcha.GosseractSolver, captcha.HTTPSolver, or a
+// captcha.Driver running a cleanup pipeline first), letting those be used
+// as a CaptchaSolver without re-implementing them here.
+type ImageSolverAdapter struct {
+	Solver captcha.Solver
+	Hint   captcha.Hint
+}
+
+func (a ImageSolverAdapter) Solve(ctx context.Context, imgPNG []byte) (string, float64, error) {
+	img, err := png.Decode(bytes.NewReader(imgPNG))
+	if err != nil {
+		return "", 0, fmt.Errorf("captchasolver: decoding PNG: %w", err)
+	}
+	return a.Solver.Solve(ctx, img, a.Hint)
+}
+
+// OCRSpaceSolver calls the ocr.space API, the original (and still
+// default) backend behind fastProcessCaptcha. ocr.space doesn't report a
+// confidence score, so a successful parse always reports 1.
+type OCRSpaceSolver struct {
+	APIKey string // defaults to $OCR_API_KEY if empty
+	Client *http.Client
+}
+
+func (s OCRSpaceSolver) Solve(ctx context.Context, imgPNG []byte) (string, float64, error) {
+	apiKey := s.APIKey
+	if apiKey == "" {
+		apiKey = os.Getenv("OCR_API_KEY")
+	}
+	if apiKey == "" {
+		return "", 0, fmt.Errorf("captchasolver: missing OCR_API_KEY")
+	}
+
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	writer.WriteField("apikey", apiKey)
+	writer.WriteField("language", "eng")
+	writer.WriteField("OCREngine", "2")
+
+	part, err := writer.CreateFormFile("file", "captcha.png")
+	if err != nil {
+		return "", 0, fmt.Errorf("captchasolver: creating form file: %w", err)
+	}
+	if _, err := part.Write(imgPNG); err != nil {
+		return "", 0, fmt.Errorf("captchasolver: writing image data: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return "", 0, fmt.Errorf("captchasolver: closing writer: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "https://api.ocr.space/parse/image", body)
+	if err != nil {
+		return "", 0, fmt.Errorf("captchasolver: building request: %w", err)
+	}
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	client := s.Client
+	if client == nil {
+		client = &http.Client{Timeout: 30 * time.Second}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", 0, fmt.Errorf("captchasolver: OCR request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		ParsedResults []struct {
+			ParsedText string `json:"ParsedText"`
+		} `json:"ParsedResults"`
+		ErrorMessage []string `json:"ErrorMessage"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", 0, fmt.Errorf("captchasolver: decoding OCR response: %w", err)
+	}
+
+	if len(result.ParsedResults) > 0 {
+		text := strings.ToLower(strings.TrimSpace(result.ParsedResults[0].ParsedText))
+		if text != "" {
+			return text, 1, nil
+		}
+	}
+	if len(result.ErrorMessage) > 0 {
+		return "", 0, fmt.Errorf("captchasolver: OCR API error: %v", result.ErrorMessage)
+	}
+	return "", 0, fmt.Errorf("captchasolver: no text found in OCR response")
+}
+
+// JFBYMSolver calls a jfbym/ym-style CAPTCHA API: POST a base64 image plus
+// a token as JSON, and read the recognized text back from
+// data.recognition. These services don't report a confidence score
+// either, so a successful parse always reports 1.
+type JFBYMSolver struct {
+	Endpoint string // e.g. "https://api.jfbym.com/api/YmServer/customApi"
+	Token    string
+	Type     string // the service's captcha-type code, if it requires one
+	Client   *http.Client
+}
+
+func (s JFBYMSolver) Solve(ctx context.Context, imgPNG []byte) (string, float64, error) {
+	payload := map[string]string{
+		"image": base64.StdEncoding.EncodeToString(imgPNG),
+		"token": s.Token,
+	}
+	if s.Type != "" {
+		payload["type"] = s.Type
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return "", 0, fmt.Errorf("captchasolver: marshaling request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return "", 0, fmt.Errorf("captchasolver: building request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := s.Client
+	if client == nil {
+		client = &http.Client{Timeout: 30 * time.Second}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", 0, fmt.Errorf("captchasolver: request to %s failed: %w", s.Endpoint, err)
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		Data struct {
+			Recognition string `json:"recognition"`
+		} `json:"data"`
+		Msg string `json:"msg"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", 0, fmt.Errorf("captchasolver: decoding response: %w", err)
+	}
+
+	text := strings.TrimSpace(result.Data.Recognition)
+	if text == "" {
+		return "", 0, fmt.Errorf("captchasolver: %s returned no recognition (msg=%q)", s.Endpoint, result.Msg)
+	}
+	return text, 1, nil
+}
+
+// MultiSolver combines Solvers according to Strategy, implementing
+// CaptchaSolver itself so it can be dropped in wherever a single solver is
+// expected (including as one entry in another MultiSolver's Solvers).
+type MultiSolver struct {
+	Solvers       []CaptchaSolver
+	Strategy      CaptchaStrategy
+	MinConfidence float64 // used by ConfidenceThreshold; defaults to 0.8
+}
+
+func (m MultiSolver) Solve(ctx context.Context, imgPNG []byte) (string, float64, error) {
+	if len(m.Solvers) == 0 {
+		return "", 0, fmt.Errorf("captchasolver: no solvers configured")
+	}
+
+	switch m.Strategy {
+	case MajorityVote:
+		return m.solveMajorityVote(ctx, imgPNG)
+	case ConfidenceThreshold:
+		return m.solveConfidenceThreshold(ctx, imgPNG)
+	case ParallelBestConfidence:
+		return m.solveParallelBestConfidence(ctx, imgPNG)
+	default:
+		return m.solveFirstSuccess(ctx, imgPNG)
+	}
+}
+
+func (m MultiSolver) solveFirstSuccess(ctx context.Context, imgPNG []byte) (string, float64, error) {
+	var lastErr error
+	for _, s := range m.Solvers {
+		text, confidence, err := s.Solve(ctx, imgPNG)
+		if err == nil {
+			return text, confidence, nil
+		}
+		lastErr = err
+	}
+	return "", 0, fmt.Errorf("captchasolver: every solver failed: %w", lastErr)
+}
+
+func (m MultiSolver) solveConfidenceThreshold(ctx context.Context, imgPNG []byte) (string, float64, error) {
+	minConfidence := m.MinConfidence
+	if minConfidence <= 0 {
+		minConfidence = 0.8
+	}
+
+	bestText := ""
+	bestConfidence := -1.0
+	var lastErr error
+	for _, s := range m.Solvers {
+		text, confidence, err := s.Solve(ctx, imgPNG)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if confidence >= minConfidence {
+			return text, confidence, nil
+		}
+		if confidence > bestConfidence {
+			bestText, bestConfidence = text, confidence
+		}
+	}
+
+	if bestConfidence >= 0 {
+		return bestText, bestConfidence, nil
+	}
+	return "", 0, fmt.Errorf("captchasolver: every solver failed: %w", lastErr)
+}
+
+func (m MultiSolver) solveMajorityVote(ctx context.Context, imgPNG []byte) (string, float64, error) {
+	type tally struct {
+		count      int
+		confidence float64
+	}
+	votes := make(map[string]*tally)
+	var order []string
+	var lastErr error
+
+	for _, s := range m.Solvers {
+		text, confidence, err := s.Solve(ctx, imgPNG)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		t, ok := votes[text]
+		if !ok {
+			t = &tally{}
+			votes[text] = t
+			order = append(order, text)
+		}
+		t.count++
+		if confidence > t.confidence {
+			t.confidence = confidence
+		}
+	}
+
+	if len(order) == 0 {
+		return "", 0, fmt.Errorf("captchasolver: every solver failed: %w", lastErr)
+	}
+
+	winner := order[0]
+	for _, text := range order[1:] {
+		if votes[text].count > votes[winner].count {
+			winner = text
+		}
+	}
+	return winner, votes[winner].confidence, nil
+}
+
+func (m MultiSolver) solveParallelBestConfidence(ctx context.Context, imgPNG []byte) (string, float64, error) {
+	type result struct {
+		text       string
+		confidence float64
+		err        error
+	}
+	results := make([]result, len(m.Solvers))
+
+	var wg sync.WaitGroup
+	for i, s := range m.Solvers {
+		wg.Add(1)
+		go func(i int, s CaptchaSolver) {
+			defer wg.Done()
+			text, confidence, err := s.Solve(ctx, imgPNG)
+			results[i] = result{text: text, confidence: confidence, err: err}
+		}(i, s)
+	}
+	wg.Wait()
+
+	best := -1
+	var lastErr error
+	for i, r := range results {
+		if r.err != nil {
+			lastErr = r.err
+			continue
+		}
+		if best == -1 || r.confidence > results[best].confidence {
+			best = i
+		}
+	}
+
+	if best == -1 {
+		return "", 0, fmt.Errorf("captchasolver: every solver failed: %w", lastErr)
+	}
+	return results[best].text, results[best].confidence, nil
+}
+
+// MetricsSolver wraps a CaptchaSolver and appends one NDJSON line per
+// Solve call to Path — the solver's name, outcome, confidence, and
+// latency — the same append-one-line-per-event style as NDJSONSink, so an
+// operator comparing several solvers (e.g. as fan-out candidates in a
+// MultiSolver) can see from disk which one is actually worth keeping.
+type MetricsSolver struct {
+	Solver CaptchaSolver
+	Name   string // identifies this solver in the log; defaults to fmt.Sprintf("%T", Solver)
+	Path   string // defaults to "logs/captcha_metrics.ndjson"
+
+	mu sync.Mutex
+}
+
+func (s *MetricsSolver) name() string {
+	if s.Name != "" {
+		return s.Name
+	}
+	return fmt.Sprintf("%T", s.Solver)
+}
+
+func (s *MetricsSolver) path() string {
+	if s.Path != "" {
+		return s.Path
+	}
+	return filepath.Join("logs", "captcha_metrics.ndjson")
+}
+
+func (s *MetricsSolver) Solve(ctx context.Context, imgPNG []byte) (string, float64, error) {
+	start := time.Now()
+	text, confidence, err := s.Solver.Solve(ctx, imgPNG)
+	s.record(time.Since(start), confidence, err)
+	return text, confidence, err
+}
+
+func (s *MetricsSolver) record(latency time.Duration, confidence float64, cause error) {
+	entry := struct {
+		Solver     string    `json:"solver"`
+		Success    bool      `json:"success"`
+		Confidence float64   `json:"confidence"`
+		Error      string    `json:"error,omitempty"`
+		LatencyMS  int64     `json:"latency_ms"`
+		Time       time.Time `json:"time"`
+	}{
+		Solver:     s.name(),
+		Success:    cause == nil,
+		Confidence: confidence,
+		LatencyMS:  latency.Milliseconds(),
+		Time:       time.Now(),
+	}
+	if cause != nil {
+		entry.Error = cause.Error()
+	}
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(s.path()), 0755); err != nil {
+		LogToFile("❌ captcha metrics: creating %s: %v", filepath.Dir(s.path()), err)
+		return
+	}
+	f, err := os.OpenFile(s.path(), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		LogToFile("❌ captcha metrics: opening %s: %v", s.path(), err)
+		return
+	}
+	defer f.Close()
+	f.Write(append(line, '\n'))
+}
@@ -0,0 +1,106 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/chromedp/cdproto/network"
+	"github.com/chromedp/chromedp"
+)
+
+// editThisCookie mirrors the JSON array the EditThisCookie browser
+// extension exports/imports, so a profile's cookie jar saved from a
+// manual, headed login can be loaded straight into the scraper (and vice
+// versa).
+type editThisCookie struct {
+	Domain         string  `json:"domain"`
+	ExpirationDate float64 `json:"expirationDate,omitempty"`
+	HostOnly       bool    `json:"hostOnly"`
+	HTTPOnly       bool    `json:"httpOnly"`
+	Name           string  `json:"name"`
+	Path           string  `json:"path"`
+	SameSite       string  `json:"sameSite"`
+	Secure         bool    `json:"secure"`
+	Session        bool    `json:"session"`
+	StoreID        string  `json:"storeId"`
+	Value          string  `json:"value"`
+}
+
+// ExportCookies reads the browser's full cookie jar via
+// network.GetAllCookies and writes it to path as EditThisCookie-format
+// JSON, so localStorage-adjacent device-fingerprint cookies survive
+// alongside SID/TIXUISID instead of being dropped between runs.
+func ExportCookies(ctx context.Context, path string) error {
+	var cookies []*network.Cookie
+	err := chromedp.Run(ctx, chromedp.ActionFunc(func(ctx context.Context) error {
+		var err error
+		cookies, err = network.GetAllCookies().Do(ctx)
+		return err
+	}))
+	if err != nil {
+		return fmt.Errorf("cookies: reading cookie jar: %w", err)
+	}
+
+	exported := make([]editThisCookie, 0, len(cookies))
+	for _, c := range cookies {
+		exported = append(exported, editThisCookie{
+			Domain:         c.Domain,
+			ExpirationDate: c.Expires,
+			HostOnly:       !strings.HasPrefix(c.Domain, "."),
+			HTTPOnly:       c.HTTPOnly,
+			Name:           c.Name,
+			Path:           c.Path,
+			SameSite:       string(c.SameSite),
+			Secure:         c.Secure,
+			Session:        c.Session,
+			StoreID:        "0",
+			Value:          c.Value,
+		})
+	}
+
+	data, err := json.MarshalIndent(exported, "", "  ")
+	if err != nil {
+		return fmt.Errorf("cookies: marshaling cookies: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("cookies: writing %s: %w", path, err)
+	}
+	return nil
+}
+
+// ImportCookies reads an EditThisCookie-format JSON file at path and
+// injects every cookie into the browser via network.SetCookie, the same
+// builder setupCookies already uses for SID/TIXUISID.
+func ImportCookies(ctx context.Context, path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("cookies: reading %s: %w", path, err)
+	}
+
+	var cookies []editThisCookie
+	if err := json.Unmarshal(data, &cookies); err != nil {
+		return fmt.Errorf("cookies: parsing %s: %w", path, err)
+	}
+
+	actions := make([]chromedp.Action, 0, len(cookies))
+	for _, c := range cookies {
+		set := network.SetCookie(c.Name, c.Value).
+			WithDomain(c.Domain).
+			WithPath(c.Path).
+			WithHTTPOnly(c.HTTPOnly).
+			WithSecure(c.Secure)
+		if c.ExpirationDate > 0 {
+			set = set.WithExpires(timeToCDPTime(time.Unix(int64(c.ExpirationDate), 0)))
+		}
+		actions = append(actions, set)
+	}
+
+	if err := chromedp.Run(ctx, actions...); err != nil {
+		return fmt.Errorf("cookies: importing cookies: %w", err)
+	}
+	return nil
+}
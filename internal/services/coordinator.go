@@ -0,0 +1,159 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// WorkerConfig is one account's assignment within a Coordinator run: its
+// own ScraperConfig (own SessionID, optional Proxy, optional ProfileDir),
+// plus the startup jitter applied before it begins.
+type WorkerConfig struct {
+	Scraper ScraperConfig
+
+	// MaxStartupJitter bounds a random delay applied before this worker's
+	// first request, so N accounts hitting the same event at the same
+	// instant don't look like a single coordinated bot. 0 means no delay.
+	MaxStartupJitter time.Duration
+}
+
+// CoordinatorConfig controls a Coordinator run across every worker.
+type CoordinatorConfig struct {
+	Workers []WorkerConfig
+
+	// MaxConcurrentBrowsers bounds how many chromedp browser instances run
+	// at once, regardless of len(Workers). <= 0 means unbounded (every
+	// worker starts immediately).
+	MaxConcurrentBrowsers int
+
+	// MaxTicketsGlobal is the total number of tickets every worker may
+	// claim between them; 0 means unlimited. Tracked off each worker's
+	// successful Booking.NumOfTickets via an internal BookingSink, so
+	// reaching the budget stops every worker as soon as it next checks
+	// ctx.Done() — not mid-flight.
+	MaxTicketsGlobal int
+}
+
+// Coordinator runs many ScraperConfigs concurrently, each through its own
+// RunScraper call (and therefore its own chromedp.NewExecAllocator
+// instance via getBrowserContext), bounded by MaxConcurrentBrowsers and a
+// MaxTicketsGlobal budget shared across workers. This replaces hand-rolling
+// N goroutines each calling RunScraper directly (which is how the GUI
+// currently runs multiple bots, see gui.startBot) with one place that
+// enforces a global browser cap and a global ticket budget across them.
+type Coordinator struct {
+	cfg CoordinatorConfig
+
+	ticketsClaimed int64 // atomic; counts toward cfg.MaxTicketsGlobal
+}
+
+// NewCoordinator returns a Coordinator ready to Run cfg.
+func NewCoordinator(cfg CoordinatorConfig) *Coordinator {
+	return &Coordinator{cfg: cfg}
+}
+
+// Run starts every worker concurrently and blocks until they've all
+// finished — because their own loop completed, ctx was cancelled, or
+// MaxTicketsGlobal was reached and Run cancelled the rest.
+func (c *Coordinator) Run(ctx context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	sem := make(chan struct{}, c.maxConcurrentBrowsers())
+
+	var wg sync.WaitGroup
+	for i, worker := range c.cfg.Workers {
+		wg.Add(1)
+		go func(id int, w WorkerConfig) {
+			defer wg.Done()
+
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+			defer func() { <-sem }()
+
+			if w.MaxStartupJitter > 0 {
+				jitter := time.Duration(rand.Int63n(int64(w.MaxStartupJitter)))
+				c.logWorkerEvent(id, w.Scraper.SessionID, "⏱️ Delaying start by %s to desynchronize from other workers", jitter)
+				select {
+				case <-time.After(jitter):
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			c.runWorker(ctx, id, w, cancel)
+		}(i, worker)
+	}
+
+	wg.Wait()
+}
+
+func (c *Coordinator) maxConcurrentBrowsers() int {
+	if c.cfg.MaxConcurrentBrowsers > 0 {
+		return c.cfg.MaxConcurrentBrowsers
+	}
+	return len(c.cfg.Workers)
+}
+
+func (c *Coordinator) runWorker(ctx context.Context, id int, w WorkerConfig, stopAll context.CancelFunc) {
+	cfg := w.Scraper
+	if c.cfg.MaxTicketsGlobal > 0 {
+		cfg.Sinks = append(append([]BookingSink(nil), cfg.Sinks...), &coordinatorBudgetSink{
+			coordinator: c,
+			stopAll:     stopAll,
+		})
+	}
+
+	c.logWorkerEvent(id, cfg.SessionID, "🚀 Worker starting")
+	RunScraper(ctx, cfg)
+	c.logWorkerEvent(id, cfg.SessionID, "🏁 Worker finished")
+}
+
+// logWorkerEvent records a Coordinator-level lifecycle event (start, stop,
+// budget exhaustion), tagged with the worker's id and session so it's
+// distinguishable from the unprefixed per-worker logs RunScraper itself
+// writes via LogToFile — threading a worker id through every one of
+// RunScraper's existing LogToFile calls would mean touching most of this
+// package, well beyond what a Coordinator needs to add.
+func (c *Coordinator) logWorkerEvent(id int, sessionID string, format string, args ...interface{}) {
+	prefix := fmt.Sprintf("[worker-%d/%s] ", id, sessionID)
+	message := prefix + fmt.Sprintf(format, args...)
+
+	LogToFile("%s", message)
+	if guiLogWriter != nil {
+		guiLogWriter.Write([]byte(fmt.Sprintf("%s %s\n", time.Now().Format("15:04:05"), message)))
+	}
+}
+
+// coordinatorBudgetSink is appended to every worker's ScraperConfig.Sinks
+// when CoordinatorConfig.MaxTicketsGlobal > 0. It doesn't publish bookings
+// anywhere itself — it just tallies NumOfTickets across every worker via
+// atomic.AddInt64, and cancels the Coordinator's shared context once the
+// budget is exhausted so every other worker stops as soon as it next
+// checks ctx.Done().
+type coordinatorBudgetSink struct {
+	coordinator *Coordinator
+	stopAll     context.CancelFunc
+}
+
+func (s *coordinatorBudgetSink) Publish(ctx context.Context, booking Booking) error {
+	n, err := strconv.Atoi(booking.NumOfTickets)
+	if err != nil || n <= 0 {
+		n = 1
+	}
+
+	claimed := atomic.AddInt64(&s.coordinator.ticketsClaimed, int64(n))
+	if claimed >= int64(s.coordinator.cfg.MaxTicketsGlobal) {
+		LogToFile("🛑 MaxTicketsGlobal budget (%d) reached, stopping all workers", s.coordinator.cfg.MaxTicketsGlobal)
+		s.stopAll()
+	}
+	return nil
+}
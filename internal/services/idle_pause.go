@@ -0,0 +1,138 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/chromedp/chromedp"
+)
+
+// SaleSchedule controls the idle/pause keyword backoff waitForSaleOpen
+// applies before each seat-selection attempt, so a bot doesn't burn
+// captcha OCR calls (or draw attention refreshing too fast) hammering a
+// pre-sale countdown page before the vendor's sale actually opens.
+type SaleSchedule struct {
+	// TargetOpenTime is when the sale is expected to open. Idle backoff
+	// stops growing once this time is reached, shrinking back to
+	// MinBackoff so the bot is polling tightly right as tickets should
+	// appear. The zero value means no known open time; backoff still
+	// applies but never shrinks early.
+	TargetOpenTime time.Time
+
+	// IdleKeywords are page-text substrings (e.g. "Sold out", a countdown
+	// string) meaning the sale hasn't opened yet — waitForSaleOpen backs
+	// off and retries instead of treating the page as ready.
+	IdleKeywords []string
+
+	// PauseKeywords are page-text substrings (e.g. an account-blocked
+	// banner) meaning the run should stop immediately. waitForSaleOpen
+	// returns an error naming the matched keyword.
+	PauseKeywords []string
+
+	// MinBackoff/MaxBackoff bound the exponential backoff applied between
+	// polls while an idle keyword matches. Default to 1s/30s when unset.
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+}
+
+func (s SaleSchedule) minBackoff() time.Duration {
+	if s.MinBackoff > 0 {
+		return s.MinBackoff
+	}
+	return time.Second
+}
+
+func (s SaleSchedule) maxBackoff() time.Duration {
+	if s.MaxBackoff > 0 {
+		return s.MaxBackoff
+	}
+	return 30 * time.Second
+}
+
+// pageText returns the current page's visible body text, for idle/pause
+// keyword matching.
+func pageText(ctx context.Context) (string, error) {
+	var text string
+	err := chromedp.Run(ctx, chromedp.Evaluate(`document.body ? document.body.innerText : ""`, &text))
+	return text, err
+}
+
+// matchKeyword returns the first of keywords found (case-insensitively)
+// in text, if any.
+func matchKeyword(text string, keywords []string) (string, bool) {
+	lower := strings.ToLower(text)
+	for _, kw := range keywords {
+		if kw == "" {
+			continue
+		}
+		if strings.Contains(lower, strings.ToLower(kw)) {
+			return kw, true
+		}
+	}
+	return "", false
+}
+
+// waitForSaleOpen polls the current page against sched's idle/pause
+// keywords. A matched pause keyword returns an error immediately, naming
+// it, so the caller can surface why the run stopped. A matched idle
+// keyword triggers exponential backoff (reload, wait, re-check) capped at
+// sched.maxBackoff() and — once TargetOpenTime is reached — at
+// sched.minBackoff(), until no idle keyword matches, a pause keyword
+// appears, or ctx is cancelled. A SaleSchedule with no keywords configured
+// is a no-op, so existing callers/configs are unaffected.
+func waitForSaleOpen(ctx context.Context, sched SaleSchedule) error {
+	if len(sched.IdleKeywords) == 0 && len(sched.PauseKeywords) == 0 {
+		return nil
+	}
+
+	backoff := sched.minBackoff()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		text, err := pageText(ctx)
+		if err != nil {
+			return fmt.Errorf("idlepause: checking keywords: %w", err)
+		}
+
+		if kw, found := matchKeyword(text, sched.PauseKeywords); found {
+			return fmt.Errorf("idlepause: pause keyword matched: %q", kw)
+		}
+
+		kw, idle := matchKeyword(text, sched.IdleKeywords)
+		if !idle {
+			return nil
+		}
+
+		wait := backoff
+		if !sched.TargetOpenTime.IsZero() {
+			if remaining := time.Until(sched.TargetOpenTime); remaining <= 0 {
+				wait = sched.minBackoff()
+			} else if remaining < wait {
+				wait = remaining
+			}
+		}
+
+		LogToFile("⏳ Idle keyword %q matched, backing off %s before retrying", kw, wait)
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+
+		fastReloadPage(ctx)
+
+		backoff *= 2
+		if backoff > sched.maxBackoff() {
+			backoff = sched.maxBackoff()
+		}
+	}
+}
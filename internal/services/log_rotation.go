@@ -0,0 +1,72 @@
+package services
+
+import (
+	"fmt"
+	"os"
+	"sync"
+)
+
+// rotatingWriter is an io.Writer over a single log file that renames the
+// current file aside (appending ".1") and opens a fresh one once it
+// crosses maxBytes, so a long-running Coordinator session doesn't grow one
+// unbounded scraper_*.log. It keeps only the immediately-previous file —
+// good enough for "don't fill the disk" without pulling in a rotation
+// dependency this module doesn't otherwise have.
+type rotatingWriter struct {
+	mu       sync.Mutex
+	path     string
+	maxBytes int64
+	file     *os.File
+	size     int64
+}
+
+func newRotatingWriter(path string, maxBytes int64) (*rotatingWriter, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0666)
+	if err != nil {
+		return nil, err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	return &rotatingWriter{path: path, maxBytes: maxBytes, file: f, size: info.Size()}, nil
+}
+
+func (w *rotatingWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.maxBytes > 0 && w.size+int64(len(p)) > w.maxBytes {
+		if err := w.rotateLocked(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := w.file.Write(p)
+	w.size += int64(n)
+	return n, err
+}
+
+func (w *rotatingWriter) rotateLocked() error {
+	if err := w.file.Close(); err != nil {
+		return err
+	}
+	backupPath := w.path + ".1"
+	if err := os.Rename(w.path, backupPath); err != nil {
+		return fmt.Errorf("rotating log file: %w", err)
+	}
+	f, err := os.OpenFile(w.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0666)
+	if err != nil {
+		return err
+	}
+	w.file = f
+	w.size = 0
+	return nil
+}
+
+func (w *rotatingWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.file.Close()
+}
@@ -0,0 +1,29 @@
+package services
+
+import "time"
+
+// MetricsSink receives per-attempt counters from RunScraper, letting a
+// caller (the GUI's live metrics panel) observe scraper activity without
+// parsing log output. All methods must be safe to call concurrently.
+type MetricsSink interface {
+	RecordAttempt()
+	RecordSuccess()
+	RecordError()
+	RecordLatency(d time.Duration)
+}
+
+// noopMetricsSink is used whenever ScraperConfig.Metrics is nil, so call
+// sites never need a nil check.
+type noopMetricsSink struct{}
+
+func (noopMetricsSink) RecordAttempt()              {}
+func (noopMetricsSink) RecordSuccess()              {}
+func (noopMetricsSink) RecordError()                {}
+func (noopMetricsSink) RecordLatency(time.Duration) {}
+
+func metricsOrNoop(sink MetricsSink) MetricsSink {
+	if sink == nil {
+		return noopMetricsSink{}
+	}
+	return sink
+}
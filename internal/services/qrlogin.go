@@ -0,0 +1,101 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/chromedp/cdproto/network"
+	"github.com/chromedp/chromedp"
+)
+
+// QRLoginResult is what LoginByQR returns once the user has scanned the
+// vendor's login QR and the site has redirected into a logged-in session.
+type QRLoginResult struct {
+	Username   string
+	SessionID  string // the SID (also set as TIXUISID) cookie value
+	QRImagePNG []byte // the screenshotted login QR, for saving/re-displaying
+}
+
+// qrSelector matches tixcraft's login QR image across the couple of
+// element shapes vendor pages tend to use for one; update this if the
+// actual markup turns out to differ.
+const qrSelector = ".qrcode, #qrcode, img[src*='qr']"
+
+// LoginByQR is the QR-scan sibling to GetUserName: instead of validating a
+// SID the operator already has, it drives a fresh chromedp session to
+// tixcraft's login page, screenshots the QR code the page displays (and
+// renders it in the terminal — see printQRToTerminal, real under -tags
+// qrlogin), then polls for `.user-name` to appear once the user scans it
+// with their phone, and reads back the SID/TIXUISID cookies via
+// network.GetCookies. timeout bounds the whole wait for a completed scan;
+// <= 0 defaults to 2 minutes.
+func LoginByQR(ctx context.Context, timeout time.Duration) (QRLoginResult, error) {
+	if timeout <= 0 {
+		timeout = 2 * time.Minute
+	}
+
+	options := append(
+		chromedp.DefaultExecAllocatorOptions[:],
+		chromedp.Flag("disable-blink-features", "AutomationControlled"),
+		chromedp.UserAgent("Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36"),
+	)
+
+	allocCtx, cancel := chromedp.NewExecAllocator(context.Background(), options...)
+	defer cancel()
+
+	browserCtx, browserCancel := chromedp.NewContext(allocCtx)
+	defer browserCancel()
+
+	timeoutCtx, timeoutCancel := context.WithTimeout(browserCtx, timeout)
+	defer timeoutCancel()
+
+	if err := chromedp.Run(timeoutCtx,
+		chromedp.Navigate("https://tixcraft.com/login"),
+		chromedp.WaitVisible(qrSelector, chromedp.ByQuery),
+	); err != nil {
+		return QRLoginResult{}, fmt.Errorf("loginbyqr: loading login page: %w", err)
+	}
+
+	var qrPNG []byte
+	if err := chromedp.Run(timeoutCtx,
+		chromedp.Screenshot(qrSelector, &qrPNG, chromedp.NodeVisible, chromedp.ByQuery),
+	); err != nil {
+		return QRLoginResult{}, fmt.Errorf("loginbyqr: capturing QR code: %w", err)
+	}
+
+	printQRToTerminal(qrPNG)
+	LogToFile("📱 Scan the printed QR code with your phone to log in...")
+
+	var username string
+	if err := chromedp.Run(timeoutCtx,
+		chromedp.WaitVisible(".user-name", chromedp.ByQuery),
+		chromedp.Text(".user-name", &username, chromedp.ByQuery),
+	); err != nil {
+		return QRLoginResult{}, fmt.Errorf("loginbyqr: timed out waiting for QR scan: %w", err)
+	}
+
+	var cookies []*network.Cookie
+	err := chromedp.Run(timeoutCtx, chromedp.ActionFunc(func(ctx context.Context) error {
+		var err error
+		cookies, err = network.GetCookies().Do(ctx)
+		return err
+	}))
+	if err != nil {
+		return QRLoginResult{}, fmt.Errorf("loginbyqr: reading cookies: %w", err)
+	}
+
+	var sessionID string
+	for _, c := range cookies {
+		if c.Name == "SID" || c.Name == "TIXUISID" {
+			sessionID = c.Value
+			break
+		}
+	}
+	if sessionID == "" {
+		return QRLoginResult{}, fmt.Errorf("loginbyqr: logged in as %s but no SID/TIXUISID cookie found", username)
+	}
+
+	LogToFile("✅ QR login complete, logged in as: %s", username)
+	return QRLoginResult{Username: username, SessionID: sessionID, QRImagePNG: qrPNG}, nil
+}
@@ -0,0 +1,47 @@
+//go:build qrlogin
+
+package services
+
+import (
+	"bytes"
+	"image"
+
+	"github.com/makiuchi-d/gozxing"
+	qrreader "github.com/makiuchi-d/gozxing/qrcode"
+	"github.com/mdp/qrterminal/v3"
+
+	"os"
+)
+
+// printQRToTerminal decodes the screenshotted login QR back into its
+// payload string (gozxing) and renders it as an ASCII QR code in the
+// terminal (qrterminal) — so an operator SSH'd into a headless box can
+// scan it with their phone without ever seeing the PNG. This file only
+// builds with `-tags qrlogin`, since neither dependency is part of this
+// module by default; see qrlogin_terminal_stub.go for the plain build,
+// which just tells the operator where the PNG was saved instead.
+//
+// This was written without a network connection to check the installed
+// gozxing/qrterminal API surface against; double-check method names/
+// signatures against the versions you vendor before relying on it.
+func printQRToTerminal(qrPNG []byte) {
+	img, _, err := image.Decode(bytes.NewReader(qrPNG))
+	if err != nil {
+		LogToFile("⚠️ Could not decode QR screenshot for terminal rendering: %v", err)
+		return
+	}
+
+	bitmap, err := gozxing.NewBinaryBitmapFromImage(img)
+	if err != nil {
+		LogToFile("⚠️ Could not binarize QR screenshot: %v", err)
+		return
+	}
+
+	result, err := qrreader.NewQRCodeReader().Decode(bitmap, nil)
+	if err != nil {
+		LogToFile("⚠️ Could not decode QR payload, nothing rendered in the terminal: %v", err)
+		return
+	}
+
+	qrterminal.Generate(result.GetText(), qrterminal.L, os.Stdout)
+}
@@ -0,0 +1,29 @@
+//go:build !qrlogin
+
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// printQRToTerminal is the no-tag stand-in for qrlogin_terminal.go's real
+// QR-to-ASCII rendering. Without gozxing/qrterminal available, it just
+// saves the screenshot to logs/ and tells the operator where to find it;
+// build with `-tags qrlogin` to render it directly in the terminal
+// instead.
+func printQRToTerminal(qrPNG []byte) {
+	if err := os.MkdirAll("logs", 0755); err != nil {
+		LogToFile("⚠️ Could not create logs directory for QR screenshot: %v", err)
+		return
+	}
+
+	path := filepath.Join("logs", "login_qr_"+time.Now().Format("2006-01-02_15-04-05")+".png")
+	if err := os.WriteFile(path, qrPNG, 0644); err != nil {
+		LogToFile("⚠️ Could not save QR screenshot: %v", err)
+		return
+	}
+
+	LogToFile("📷 Login QR saved to %s — open it to scan (build with -tags qrlogin to render it in the terminal instead)", path)
+}
@@ -1,17 +1,15 @@
 package services
 
 import (
-	"bytes"
 	"context"
 	"crypto/rand"
 	"encoding/base64"
-	"encoding/json"
 	"fmt"
 	"io"
 	"log"
-	"mime/multipart"
-	"net/http"
+	"log/slog"
 	"os"
+	"regexp"
 	"strconv"
 	"strings"
 	"sync"
@@ -22,6 +20,11 @@ import (
 	"github.com/chromedp/cdproto/page"
 	"github.com/chromedp/chromedp"
 	"github.com/joho/godotenv"
+
+	"tix-scraper/internal/auth/totp"
+	"tix-scraper/internal/browser"
+	promMetrics "tix-scraper/internal/metrics"
+	"tix-scraper/internal/waits"
 )
 
 func init() {
@@ -40,6 +43,81 @@ type ScraperConfig struct {
 	SessionID      string
 	Loop           bool
 	PreSaleCode    string
+
+	// TOTP and TOTPSite, if both set, let handleTOTPCode fetch a live 2FA
+	// code for this bot's vendor login instead of leaving the OTP field for
+	// the operator to fill in by hand. A nil TOTP or empty TOTPSite means
+	// the bot doesn't use 2FA.
+	TOTP     *totp.Provisioner
+	TOTPSite string
+
+	// Metrics, if set, is notified of each iteration's outcome and timing.
+	// A nil Metrics is equivalent to a sink that discards everything.
+	Metrics MetricsSink
+
+	// Sinks, if set, each receive a copy of every successfully completed
+	// Booking (see BookingSink) — e.g. an NDJSON stream, a signed webhook
+	// into Discord/Slack/Home Assistant, or a SQL writer for a downstream
+	// inventory DB. A nil/empty slice just means nothing is published.
+	Sinks []BookingSink
+
+	// CaptchaSolvers, if set, replace the built-in ocr.space-only captcha
+	// handling with one or more pluggable CaptchaSolver backends, combined
+	// according to CaptchaStrategy. A nil/empty slice keeps the original
+	// single-OCRSpaceSolver behavior.
+	CaptchaSolvers []CaptchaSolver
+
+	// CaptchaStrategy selects how CaptchaSolvers are combined when more
+	// than one is configured. Defaults to FirstSuccess.
+	CaptchaStrategy CaptchaStrategy
+
+	// CaptchaMinConfidence is the minimum confidence the ConfidenceThreshold
+	// strategy requires before accepting a solver's answer. Ignored by the
+	// other strategies. Defaults to 0.8 if left at 0.
+	CaptchaMinConfidence float64
+
+	// ProfileDir, if set, is passed to chromedp.UserDataDir so the
+	// browser's cookies, localStorage, and device-fingerprint state
+	// persist across runs instead of starting from a fresh profile every
+	// time. Combine with ExportCookies/ImportCookies (see cookies.go) to
+	// seed a profile from a cookie jar saved elsewhere (e.g. a manual,
+	// headed login).
+	ProfileDir string
+
+	// Proxy, if set, is passed to chromedp.ProxyServer so this session's
+	// traffic (and therefore its apparent IP) differs from other
+	// concurrently-running sessions — see Coordinator, which assigns one
+	// per worker. Empty means no proxy, same as before this field existed.
+	Proxy string
+
+	// Browser, if set, is used by handlePreSaleCode and GetUserName for
+	// their navigate, eval, cookie, wait and click steps, so those steps can
+	// run on Playwright (see internal/browser) by setting this to a
+	// browser.PlaywrightBrowser built with -tags playwright. A nil Browser
+	// falls back to browser.ChromedpBrowser{}, the original behavior.
+	// handlePreSaleCode still types the code in with chromedp.SetValue,
+	// which the Browser interface lacks, and GetUserName still starts its
+	// own chromedp allocator. The
+	// seat-selection loop and captcha capture still use chromedp.Run
+	// directly — they rely on primitives (EvaluateAsDevTools, SetValue,
+	// SetAttributeValue, chromedp.ListenTarget, chromedp.Location) outside
+	// the Browser interface's scope.
+	Browser browser.Browser
+
+	// Store, if set, additionally records every captcha solve attempt
+	// (image hash, OCR text, success, latency, solver name) via
+	// RecordCaptchaAttempt — see BookingStore. A nil Store just means
+	// captcha attempts aren't persisted anywhere beyond the per-solver
+	// NDJSON metrics MetricsSolver already writes.
+	Store BookingStore
+
+	// SaleSchedule, if it has any keywords set, makes monitorEventPage back
+	// off (idle keywords, e.g. a countdown or "Sold out") or abort
+	// (pause keywords, e.g. an account-blocked banner) before each
+	// seat-selection attempt instead of hammering the event page with
+	// fastReloadPage. The zero value is a no-op, same as before this
+	// field existed.
+	SaleSchedule SaleSchedule
 }
 
 type Booking struct {
@@ -63,17 +141,66 @@ type Booking struct {
 
 // Global logger for file logging
 var (
-	fileLogger   *log.Logger
-	logFile      *os.File
+	fileLogger   *slog.Logger
+	logFile      *rotatingWriter
 	logMutex     sync.Mutex
 	guiLogWriter io.Writer
 )
-var fileMutex sync.Mutex
 
 func SetGUIWriter(writer io.Writer) {
 	guiLogWriter = writer
 }
 
+// guiMirrorHandler wraps a slog.Handler and, after it handles a record
+// normally (JSON to file/stdout), also writes a plain timestamped line to
+// guiLogWriter if one is set — so LogToFile keeps mirroring to the GUI's
+// log panel without the GUI having to understand slog's JSON output.
+type guiMirrorHandler struct {
+	slog.Handler
+}
+
+func (h guiMirrorHandler) Handle(ctx context.Context, r slog.Record) error {
+	if err := h.Handler.Handle(ctx, r); err != nil {
+		return err
+	}
+	if guiLogWriter != nil {
+		guiLogWriter.Write([]byte(fmt.Sprintf("%s %s\n", r.Time.Format("15:04:05"), r.Message)))
+	}
+	return nil
+}
+
+// redactedKeys are slog attribute keys whose value is replaced with
+// "[REDACTED]" before it ever reaches stdout/the log file — raw cookie
+// values and pre-sale codes are secrets an attacker could replay, unlike
+// the other structured fields (session_id, captcha_text, etc.) this chunk
+// adds, which are fine to keep for debugging failed captcha loops.
+var redactedKeys = map[string]bool{
+	"cookie":        true,
+	"pre_sale_code": true,
+}
+
+func redactAttr(groups []string, a slog.Attr) slog.Attr {
+	if redactedKeys[a.Key] {
+		a.Value = slog.StringValue("[REDACTED]")
+	}
+	return a
+}
+
+// logLevelFromEnv reads LOG_LEVEL (debug/info/warn/error, case-insensitive)
+// and falls back to slog.LevelInfo for an unset or unrecognized value.
+func logLevelFromEnv() slog.Level {
+	switch strings.ToLower(strings.TrimSpace(os.Getenv("LOG_LEVEL"))) {
+	case "debug":
+		return slog.LevelDebug
+	case "warn", "warning":
+		return slog.LevelWarn
+	case "error":
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
+
 // Initialize file logger
 func initFileLogger() error {
 	logMutex.Lock()
@@ -91,44 +218,80 @@ func initFileLogger() error {
 	filename := fmt.Sprintf("logs/scraper_%s.log", timestamp)
 
 	var err error
-	logFile, err = os.OpenFile(filename, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0666)
+	logFile, err = newRotatingWriter(filename, 20*1024*1024)
 	if err != nil {
 		return fmt.Errorf("failed to open log file: %w", err)
 	}
 
 	multiWriter := io.MultiWriter(os.Stdout, logFile)
-	fileLogger = log.New(multiWriter, "", log.LstdFlags|log.Lshortfile)
+	fileLogger = slog.New(guiMirrorHandler{slog.NewJSONHandler(multiWriter, &slog.HandlerOptions{
+		Level:       logLevelFromEnv(),
+		ReplaceAttr: redactAttr,
+	})})
 
 	return nil
 }
 
+// LogToFile keeps its original printf-style signature so none of its many
+// call sites throughout this file need to change, but now logs through
+// slog internally: a JSON handler mirrored to stdout+logFile, plus a
+// plain-text mirror to guiLogWriter via guiMirrorHandler. It always logs
+// at Info — use LogEvent for leveled, structured entries (run_id,
+// session_id, attempt, etc.) at the handful of call sites worth the extra
+// fields.
 func LogToFile(format string, v ...interface{}) {
 	message := fmt.Sprintf(format, v...)
 
-	// Write to file/terminal
 	if fileLogger == nil {
 		if err := initFileLogger(); err != nil {
 			log.Printf("Failed to initialize file logger: %v", err)
 			log.Printf("%s", message)
-		} else {
-			fileLogger.Printf("%s", message)
+			return
 		}
-	} else {
-		fileLogger.Printf("%s", message)
 	}
+	fileLogger.Info(message)
+}
 
-	// Write to GUI if available
-	if guiLogWriter != nil {
-		// Add timestamp for GUI logs
-		timestamp := time.Now().Format("15:04:05")
-		guiMessage := fmt.Sprintf("%s %s\n", timestamp, message)
-		guiLogWriter.Write([]byte(guiMessage))
+// runIDKey is the context key RunScraper stashes its per-run correlation
+// UUID under, so LogEvent calls anywhere downstream of that ctx (without
+// threading an extra parameter through every helper) can tag their entry
+// with it.
+type runIDKey struct{}
+
+// withRunID returns a child context carrying runID for LogEvent to read
+// back via runIDFromContext.
+func withRunID(ctx context.Context, runID string) context.Context {
+	return context.WithValue(ctx, runIDKey{}, runID)
+}
+
+func runIDFromContext(ctx context.Context) string {
+	runID, _ := ctx.Value(runIDKey{}).(string)
+	return runID
+}
+
+// LogEvent is LogToFile's leveled, structured counterpart: one JSON object
+// per call with msg plus whatever attrs the caller passes (session_id,
+// event_id, attempt, seat, captcha_text, duration_ms, ...), tagged with
+// the run_id stashed in ctx by RunScraper. Only call sites where those
+// fields are cheaply available and worth grepping for in Loki/ELK use
+// this; the rest of the file's narrative logging stays on LogToFile.
+func LogEvent(ctx context.Context, level slog.Level, msg string, attrs ...slog.Attr) {
+	if fileLogger == nil {
+		if err := initFileLogger(); err != nil {
+			log.Printf("Failed to initialize file logger: %v", err)
+			log.Printf("%s", msg)
+			return
+		}
+	}
+	if runID := runIDFromContext(ctx); runID != "" {
+		attrs = append(attrs, slog.String("run_id", runID))
 	}
+	fileLogger.LogAttrs(ctx, level, msg, attrs...)
 }
 
 // Global browser context for reuse
 
-func getBrowserContext(parentCtx context.Context) (context.Context, context.CancelFunc) {
+func getBrowserContext(parentCtx context.Context, profileDir string, proxy string) (context.Context, context.CancelFunc) {
 	opts := append(chromedp.DefaultExecAllocatorOptions[:],
 
 		// CRITICAL: Must be true for CLI/server environment
@@ -140,6 +303,18 @@ func getBrowserContext(parentCtx context.Context) (context.Context, context.Canc
 		chromedp.UserAgent("Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36"),
 	)
 
+	// ProfileDir lets cookies/localStorage/device-fingerprint state survive
+	// across runs instead of starting from a clean profile every time.
+	if profileDir != "" {
+		opts = append(opts, chromedp.UserDataDir(profileDir))
+	}
+
+	// Proxy gives this session its own apparent IP, so multiple concurrent
+	// Coordinator workers hitting the same event don't share one.
+	if proxy != "" {
+		opts = append(opts, chromedp.ProxyServer(proxy))
+	}
+
 	allocCtx, allocCancel := chromedp.NewExecAllocator(context.Background(), opts...)
 	browserCtx, browserCancel := chromedp.NewContext(allocCtx)
 
@@ -178,9 +353,46 @@ func setupPopupHandler(ctx context.Context) {
 	})
 }
 
+// ticketOrOrderURL matches the URL runMainFlow expects right after a
+// successful executeBookingFlow/processTicketPage submission, so it can
+// wait for the real redirect via waits.WaitForURLMatch instead of
+// sleeping a guessed duration.
+var ticketOrOrderURL = regexp.MustCompile(`tixcraft\.com/ticket/(ticket|order|checkout)`)
+
+// checkoutURL matches the final checkout redirect runMainFlow waits for
+// after the order page.
+var checkoutURL = regexp.MustCompile(`tixcraft\.com/ticket/checkout`)
+
+// urlRouteLabel reduces a full page URL to the short route name
+// runMainFlow's switch below routes on, so the tix_page_transition_seconds
+// and tix_current_url metric labels stay low-cardinality instead of
+// exploding with the query strings/session IDs baked into the real URL.
+func urlRouteLabel(url string) string {
+	switch {
+	case strings.Contains(url, "https://tixcraft.com/activity/game/"):
+		return "event"
+	case strings.Contains(url, "https://tixcraft.com/ticket/area/"):
+		return "area"
+	case strings.Contains(url, "https://tixcraft.com/ticket/ticket/"):
+		return "ticket"
+	case strings.Contains(url, "https://tixcraft.com/ticket/verify"):
+		return "verify"
+	case strings.Contains(url, "https://tixcraft.com/ticket/order"):
+		return "order"
+	case strings.Contains(url, "https://tixcraft.com/ticket/checkout"):
+		return "checkout"
+	default:
+		return "unknown"
+	}
+}
+
 // Main runner function with URL-based routing
 // Main runner function with URL-based routing
-func runMainFlow(ctx context.Context, cfg *ScraperConfig, isFirstIteration bool) bool {
+func runMainFlow(ctx context.Context, cfg *ScraperConfig, isFirstIteration bool, iteration int) bool {
+	iterationLabel := strconv.Itoa(iteration)
+	var fromRoute string
+	transitionStart := time.Now()
+
 	for {
 		select {
 		case <-ctx.Done():
@@ -197,6 +409,13 @@ func runMainFlow(ctx context.Context, cfg *ScraperConfig, isFirstIteration bool)
 
 		LogToFile("🌐 Current URL: %s", currentURL)
 
+		toRoute := urlRouteLabel(currentURL)
+		promMetrics.CurrentURL.Set(1, iterationLabel, toRoute)
+		if fromRoute != "" {
+			promMetrics.PageTransitions.Observe(time.Since(transitionStart).Seconds(), fromRoute, toRoute)
+		}
+		fromRoute, transitionStart = toRoute, time.Now()
+
 		// Route based on URL pattern
 		switch {
 		case strings.Contains(currentURL, "https://tixcraft.com/activity/game/"):
@@ -213,9 +432,11 @@ func runMainFlow(ctx context.Context, cfg *ScraperConfig, isFirstIteration bool)
 			if !success {
 				return false
 			}
-			// After executeBookingFlow succeeds, wait for next URL
-			// It should redirect to ticket/ticket/ or ticket/order
-			time.Sleep(2 * time.Second)
+			// After executeBookingFlow succeeds, wait for the redirect to
+			// ticket/ticket/ or ticket/order instead of sleeping blind.
+			if _, err := waits.WaitForURLMatch(ctx, ticketOrOrderURL, 5*time.Second); err != nil {
+				LogToFile("⚠️ No redirect detected after booking flow: %v", err)
+			}
 			continue
 
 		case strings.Contains(currentURL, "https://tixcraft.com/ticket/ticket/"):
@@ -224,8 +445,11 @@ func runMainFlow(ctx context.Context, cfg *ScraperConfig, isFirstIteration bool)
 			if !success {
 				return false
 			}
-			// After processTicketPage succeeds, wait for redirect
-			time.Sleep(2 * time.Second)
+			// After processTicketPage succeeds, wait for the redirect
+			// instead of sleeping blind.
+			if _, err := waits.WaitForURLMatch(ctx, ticketOrOrderURL, 5*time.Second); err != nil {
+				LogToFile("⚠️ No redirect detected after ticket page: %v", err)
+			}
 			continue
 
 		case strings.Contains(currentURL, "https://tixcraft.com/ticket/verify"):
@@ -235,12 +459,9 @@ func runMainFlow(ctx context.Context, cfg *ScraperConfig, isFirstIteration bool)
 
 		case strings.Contains(currentURL, "https://tixcraft.com/ticket/order"):
 			LogToFile("🔍 On order page, waiting for redirect...")
-			// Just wait for redirect to checkout
-			err := chromedp.Run(ctx,
-				chromedp.Sleep(2*time.Second),
-			)
-			if err != nil {
-				LogToFile("❌ Error on order page: %v", err)
+			// Wait for the redirect to checkout instead of sleeping blind.
+			if _, err := waits.WaitForURLMatch(ctx, checkoutURL, 8*time.Second); err != nil {
+				LogToFile("⚠️ No redirect to checkout detected: %v", err)
 			}
 			continue
 
@@ -260,18 +481,37 @@ func runMainFlow(ctx context.Context, cfg *ScraperConfig, isFirstIteration bool)
 	}
 }
 
-func RunScraper(ctx context.Context, cfg ScraperConfig) {
+// RunScraper drives one scraping session to completion (or until ctx is
+// cancelled) and reports whether it actually succeeded: a non-nil error
+// here is what lets callers like cli.runScraperSupervised's retry
+// supervisor and per-event circuit breaker react to a real failure,
+// instead of treating every return as a clean exit.
+func RunScraper(ctx context.Context, cfg ScraperConfig) error {
 	if err := initFileLogger(); err != nil {
 		log.Printf("❌ Failed to initialize file logger: %v", err)
 	} else {
 		defer logFile.Close()
 	}
 
-	LogToFile("🚀 Starting scraper with config: EventID=%s, TicketID=%s, Filter=%s",
-		cfg.EventID, cfg.TicketID, cfg.Filter)
+	// runID correlates every LogEvent entry for this booking attempt — one
+	// RunScraper call, one run_id — so a grep across concurrent Coordinator
+	// workers' logs can isolate a single session's events.
+	runID := generateUUID()
+	ctx = withRunID(ctx, runID)
 
-	// Create NEW browser context for each session
-	browserCtx, browserCancel := getBrowserContext(ctx)
+	LogEvent(ctx, slog.LevelInfo, "starting scraper",
+		slog.String("session_id", cfg.SessionID),
+		slog.String("event_id", cfg.EventID),
+		slog.String("ticket_id", cfg.TicketID),
+	)
+
+	// Create NEW browser context for each session. getBrowserContext builds
+	// browserCtx off context.Background() (so chromedp survives ctx being
+	// reused/cancelled independently of the browser), which would otherwise
+	// drop the run_id value set above — re-attach it so downstream
+	// LogEvent calls against browserCtx still carry it.
+	browserCtx, browserCancel := getBrowserContext(ctx, cfg.ProfileDir, cfg.Proxy)
+	browserCtx = withRunID(browserCtx, runID)
 	defer browserCancel()
 
 	// Determine Loop Count
@@ -279,13 +519,13 @@ func RunScraper(ctx context.Context, cfg ScraperConfig) {
 	quantity, err := strconv.Atoi(cfg.PerOrderTicket)
 	if err != nil {
 		LogToFile("❌ Invalid quantity format: %s", cfg.PerOrderTicket)
-		return
+		return fmt.Errorf("runscraper: invalid quantity %q: %w", cfg.PerOrderTicket, err)
 	}
 
 	maxTickets, err := strconv.Atoi(cfg.MaxTickets)
 	if err != nil {
 		LogToFile("❌ Invalid max tickets format: %s", cfg.MaxTickets)
-		return
+		return fmt.Errorf("runscraper: invalid max tickets %q: %w", cfg.MaxTickets, err)
 	}
 
 	if cfg.Loop && quantity > 0 && maxTickets > 0 {
@@ -304,26 +544,33 @@ func RunScraper(ctx context.Context, cfg ScraperConfig) {
 	)
 	if err != nil {
 		LogToFile("❌ Initial navigation failed: %v", err)
-		return
+		return fmt.Errorf("runscraper: initial navigation failed: %w", err)
 	}
 
 	// Dismiss cookie banner on first iteration
 	// dismissCookieBanner(browserCtx)
 
+	metrics := metricsOrNoop(cfg.Metrics)
+
 	// Execute main flow for each iteration
 	for i := 1; i <= numLoops; {
 		select {
 		case <-ctx.Done():
 			LogToFile("⏹️ Scraper stopped by user.")
-			return
+			return ctx.Err()
 		default:
 		}
 
 		LogToFile("=== Iteration %d/%d ===", i, numLoops)
 
-		success := runMainFlow(browserCtx, &cfg, i == 1)
+		metrics.RecordAttempt()
+		attemptStart := time.Now()
+		success := runMainFlow(browserCtx, &cfg, i == 1, i)
+		metrics.RecordLatency(time.Since(attemptStart))
 
 		if success {
+			metrics.RecordSuccess()
+			promMetrics.Iterations.Inc("success")
 			i++
 			if i <= numLoops {
 				LogToFile("✅ Success!")
@@ -331,12 +578,15 @@ func RunScraper(ctx context.Context, cfg ScraperConfig) {
 				// resetBrowserState(browserCtx, cfg)
 			}
 		} else {
+			metrics.RecordError()
+			promMetrics.Iterations.Inc("error")
 			LogToFile("❌ Iteration failed. Retrying in 2 seconds...")
 			time.Sleep(2 * time.Second)
 			resetBrowserState(browserCtx, cfg)
 		}
 	}
 	LogToFile("🎉 All iterations complete.")
+	return nil
 }
 
 func resetBrowserState(ctx context.Context, cfg ScraperConfig) {
@@ -392,14 +642,23 @@ func processTicketPage(ctx context.Context, cfg *ScraperConfig) bool {
 		default:
 		}
 
-		captchaText, err := fastProcessCaptcha(ctx)
+		captchaStart := time.Now()
+		captchaText, err := solveCaptcha(ctx, *cfg)
+		captchaDuration := time.Since(captchaStart)
 		if err != nil {
 			LogToFile("❌ Captcha error: %v", err)
+			promMetrics.CaptchaAttempts.Inc("solve_error")
 			fastReloadPage(ctx)
 			continue
 		}
 
 		LogToFile("🔐 Attempting Captcha: %s", captchaText)
+		LogEvent(ctx, slog.LevelInfo, "attempting captcha",
+			slog.String("event_id", cfg.EventID),
+			slog.Int("attempt", j+1),
+			slog.String("captcha_text", captchaText),
+			slog.Int64("duration_ms", captchaDuration.Milliseconds()),
+		)
 
 		var currentURL, newURL string
 		var errorMessage string
@@ -410,31 +669,40 @@ func processTicketPage(ctx context.Context, cfg *ScraperConfig) bool {
 			chromedp.SetValue("#TicketForm_verifyCode", captchaText, chromedp.ByQuery),
 			chromedp.SetAttributeValue("#TicketForm_agree", "checked", "true", chromedp.ByQuery),
 			chromedp.Click("button[type='submit']", chromedp.ByQuery),
-			chromedp.Sleep(2000*time.Millisecond),
+			waits.Action(func(waitCtx context.Context) error {
+				// Wait for the submission's navigation rather than
+				// sleeping a guessed duration; a miss isn't fatal since
+				// the error-message check and final Location below still
+				// decide success/failure.
+				_, _ = waits.WaitForNavigationTo(waitCtx, func(url string) bool {
+					return url != currentURL
+				}, 3*time.Second)
+				return nil
+			}),
 			chromedp.Evaluate(`
 				(function() {
 					const errorSelectors = [
 						'.alert-danger',
-						'.error-message', 
+						'.error-message',
 						'.text-danger',
 						'#error-message',
 						'.verifyCode-error',
 						'[class*="error"]',
 						'[class*="invalid"]'
 					];
-					
+
 					for (const selector of errorSelectors) {
 						const element = document.querySelector(selector);
 						if (element && element.textContent.trim()) {
 							return element.textContent.trim();
 						}
 					}
-					
+
 					const captchaError = document.querySelector('#TicketForm_verifyCode-error');
 					if (captchaError && captchaError.textContent.trim()) {
 						return captchaError.textContent.trim();
 					}
-					
+
 					return "";
 				})()
 			`, &errorMessage),
@@ -443,22 +711,30 @@ func processTicketPage(ctx context.Context, cfg *ScraperConfig) bool {
 
 		if err != nil {
 			LogToFile("❌ Submission error: %v", err)
+			promMetrics.CaptchaAttempts.Inc("solve_error")
 			fastReloadPage(ctx)
 			continue
 		}
 
 		if errorMessage != "" {
 			LogToFile("❌ Submission failed: %s", errorMessage)
+			promMetrics.CaptchaAttempts.Inc("rejected")
 			fastReloadPage(ctx)
 			continue
 		}
 
 		if newURL != currentURL {
 			LogToFile("🎉 Reservation Successful!")
+			LogEvent(ctx, slog.LevelInfo, "reservation successful",
+				slog.String("event_id", cfg.EventID),
+				slog.Int("attempt", j+1),
+			)
+			promMetrics.CaptchaAttempts.Inc("solved")
 			return true
 		}
 
 		LogToFile("🔁 No URL change or success indicator, retrying captcha... (%d/%d)", j+1, maxCaptchaRetries)
+		promMetrics.CaptchaAttempts.Inc("rejected")
 		fastReloadPage(ctx)
 	}
 
@@ -622,71 +898,6 @@ func fastCheckoutExtract(ctx context.Context, cfg ScraperConfig) error {
 	return fmt.Errorf("unexpected state in checkout extraction")
 }
 
-// Safe file operations with retry mechanism
-
-func safeSaveBooking(booking Booking) {
-	fileMutex.Lock()
-	defer fileMutex.Unlock()
-
-	// Retry mechanism for file operations
-	for attempt := 1; attempt <= 3; attempt++ {
-		if err := os.MkdirAll("data", 0755); err != nil {
-			LogToFile("❌ Attempt %d: Failed to create data directory: %v", attempt, err)
-			time.Sleep(1 * time.Second)
-			continue
-		}
-
-		var bookings []Booking
-		filename := "data/bookings.json"
-
-		// Read existing data
-		data, err := os.ReadFile(filename)
-		if err != nil && !os.IsNotExist(err) {
-			LogToFile("❌ Attempt %d: Error reading bookings file: %v", attempt, err)
-			time.Sleep(1 * time.Second)
-			continue
-		}
-
-		if len(data) > 0 {
-			if err := json.Unmarshal(data, &bookings); err != nil {
-				LogToFile("❌ Attempt %d: Error unmarshaling existing bookings: %v", attempt, err)
-				// Start fresh if file is corrupted
-				bookings = []Booking{}
-			}
-		}
-
-		// Append new booking
-		bookings = append(bookings, booking)
-
-		// Write with temporary file to prevent corruption
-		tempFilename := filename + ".tmp"
-		updatedData, err := json.MarshalIndent(bookings, "", "  ")
-		if err != nil {
-			LogToFile("❌ Attempt %d: Error marshaling bookings: %v", attempt, err)
-			time.Sleep(1 * time.Second)
-			continue
-		}
-
-		if err := os.WriteFile(tempFilename, updatedData, 0644); err != nil {
-			LogToFile("❌ Attempt %d: Error writing temp file: %v", attempt, err)
-			time.Sleep(1 * time.Second)
-			continue
-		}
-
-		// Atomic rename
-		if err := os.Rename(tempFilename, filename); err != nil {
-			LogToFile("❌ Attempt %d: Error renaming temp file: %v", attempt, err)
-			time.Sleep(1 * time.Second)
-			continue
-		}
-
-		LogToFile("✅ Booking saved to file. Order: %s", booking.OrderNumber)
-		return
-	}
-
-	LogToFile("❌ Failed to save booking after 3 attempts")
-}
-
 func dismissCookieBanner(ctx context.Context) {
 	// Try to click any cookie acceptance button that appears
 	// log.Println("First iteration: dismissing cookie banner if present")
@@ -726,6 +937,11 @@ func monitorEventPage(ctx context.Context, cfg ScraperConfig) error {
 		default:
 		}
 
+		if err := waitForSaleOpen(ctx, cfg.SaleSchedule); err != nil {
+			LogToFile("🛑 Sale schedule aborted monitoring: %v", err)
+			return err
+		}
+
 		var result string
 
 		// Wait for page to load and check for tickets
@@ -800,11 +1016,16 @@ func executeBookingFlow(ctx context.Context, cfg ScraperConfig, isFirstIteration
 	// LogToFile("✅ Logged in as: %s", username)
 
 	LogToFile("🔍 Checking for pre-sale code requirement...")
-	if err := handlePreSaleCode(ctx, cfg.PreSaleCode); err != nil {
+	if err := handlePreSaleCode(ctx, cfg.PreSaleCode, cfg.Browser); err != nil {
 		LogToFile("❌ Pre-sale code error: %v", err)
 		return false
 	}
 
+	if err := handleTOTPCode(ctx, cfg); err != nil {
+		LogToFile("❌ 2FA code error: %v", err)
+		return false
+	}
+
 	// Fast seat selection
 	var seatVal string
 	actions := []chromedp.Action{
@@ -886,7 +1107,7 @@ func executeBookingFlow(ctx context.Context, cfg ScraperConfig, isFirstIteration
 		default:
 		}
 
-		captchaText, err := fastProcessCaptcha(ctx)
+		captchaText, err := solveCaptcha(ctx, cfg)
 		if err != nil {
 			LogToFile("❌ Captcha error: %v", err)
 			fastReloadPage(ctx)
@@ -921,7 +1142,14 @@ func executeBookingFlow(ctx context.Context, cfg ScraperConfig, isFirstIteration
 
 			chromedp.SetAttributeValue("#TicketForm_agree", "checked", "true", chromedp.ByQuery),
 			chromedp.Click("button[type='submit']", chromedp.ByQuery),
-			chromedp.Sleep(2000*time.Millisecond),
+			waits.Action(func(waitCtx context.Context) error {
+				// Wait for the submission's XHR to finish rather than
+				// sleeping a guessed duration; a miss isn't fatal since
+				// the error-message check and final Location below still
+				// decide success/failure.
+				_ = waits.WaitForXHR(waitCtx, "/ticket/", 3*time.Second)
+				return nil
+			}),
 			// Check for error messages on the page
 			chromedp.Evaluate(`
             (function() {
@@ -971,24 +1199,24 @@ func executeBookingFlow(ctx context.Context, cfg ScraperConfig, isFirstIteration
 		if newURL != currentURL {
 			LogToFile("🎉 Reservation Successful! 919")
 
-			// _ = chromedp.Run(ctx,
-			// 	// chromedp.Reload(),
-			// 	chromedp.Sleep(1*time.Second),
-			// )
-			// Fast checkout extraction
-			// err := fastCheckoutExtract(ctx, cfg)
-			// if err != nil {
-			// 	LogToFile("❌ Checkout error: %v", err)
-			// 	// fastReloadPage(ctx)
-			// 	continue
-			// }
-			// if err == nil && booking != nil {
-			// 	booking.SessionID = cfg.SessionID
-			// 	booking.Seat = seatVal
-			// 	booking.EventID = cfg.EventID
-			// 	booking.UserName = username
-			// 	go saveBooking(*booking)
-			// }
+			// fastCheckoutExtract only handles the reselect-button flow, not
+			// order-number/pricing scraping, so OrderNumber/Total/etc are
+			// left blank here until checkout extraction is actually built
+			// out; publish what's already known from cfg and seatVal.
+			booking := Booking{
+				SessionID:    cfg.SessionID,
+				Seat:         seatVal,
+				EventID:      cfg.EventID,
+				TicketID:     cfg.TicketID,
+				NumOfTickets: cfg.PerOrderTicket,
+			}
+			publishBooking(ctx, cfg, booking)
+			LogEvent(ctx, slog.LevelInfo, "booking published",
+				slog.String("session_id", cfg.SessionID),
+				slog.String("event_id", cfg.EventID),
+				slog.String("seat", seatVal),
+			)
+			promMetrics.BookingSuccess.Inc(cfg.EventID)
 
 			return true
 		}
@@ -1007,10 +1235,11 @@ func fastReloadPage(ctx context.Context) {
 	)
 }
 
-func fastProcessCaptcha(ctx context.Context) (string, error) {
+// captureCaptchaImage grabs the current CAPTCHA image from the page as
+// PNG bytes, for a CaptchaSolver to recognize.
+func captureCaptchaImage(ctx context.Context) ([]byte, error) {
 	var base64Data string
 
-	// Fast captcha image capture
 	err := chromedp.Run(ctx,
 		chromedp.WaitVisible("#TicketForm_verifyCode-image", chromedp.ByID),
 		chromedp.Evaluate(`
@@ -1031,173 +1260,180 @@ func fastProcessCaptcha(ctx context.Context) (string, error) {
         `, &base64Data),
 	)
 	if err != nil || base64Data == "" {
-		return "", fmt.Errorf("captcha image capture failed: %w", err)
+		return nil, fmt.Errorf("captcha image capture failed: %w", err)
 	}
 
 	if base64Data == "image_not_loaded" {
-		return "", fmt.Errorf("captcha image not loaded yet")
+		return nil, fmt.Errorf("captcha image not loaded yet")
 	}
 	if strings.HasPrefix(base64Data, "error:") {
-		return "", fmt.Errorf("javascript error: %s", base64Data)
+		return nil, fmt.Errorf("javascript error: %s", base64Data)
 	}
 
 	parts := strings.Split(base64Data, ",")
 	if len(parts) != 2 {
-		return "", fmt.Errorf("invalid base64 format")
+		return nil, fmt.Errorf("invalid base64 format")
 	}
 
 	imageBytes, err := base64.StdEncoding.DecodeString(parts[1])
 	if err != nil {
-		return "", fmt.Errorf("base64 decode failed: %w", err)
-	}
-
-	// Fast OCR request
-	body := &bytes.Buffer{}
-	writer := multipart.NewWriter(body)
-
-	apiKey := os.Getenv("OCR_API_KEY")
-	if apiKey == "" {
-		return "", fmt.Errorf("missing OCR_API_KEY")
-	}
-
-	writer.WriteField("apikey", apiKey)
-	writer.WriteField("language", "eng")
-	writer.WriteField("OCREngine", "2")
-
-	part, err := writer.CreateFormFile("file", "captcha.png")
-	if err != nil {
-		return "", fmt.Errorf("failed to create form file: %w", err)
+		return nil, fmt.Errorf("base64 decode failed: %w", err)
 	}
+	return imageBytes, nil
+}
 
-	if _, err := part.Write(imageBytes); err != nil {
-		return "", fmt.Errorf("failed to write image data: %w", err)
+// captchaSolver builds the CaptchaSolver cfg describes: a MultiSolver over
+// cfg.CaptchaSolvers/cfg.CaptchaStrategy if set, or OCRSpaceSolver (the
+// original, pre-CaptchaSolver default) otherwise.
+func captchaSolver(cfg ScraperConfig) CaptchaSolver {
+	if len(cfg.CaptchaSolvers) == 0 {
+		return OCRSpaceSolver{}
 	}
-
-	if err := writer.Close(); err != nil {
-		return "", fmt.Errorf("failed to close writer: %w", err)
+	if len(cfg.CaptchaSolvers) == 1 {
+		return cfg.CaptchaSolvers[0]
 	}
-
-	req, err := http.NewRequest("POST", "https://api.ocr.space/parse/image", body)
-	if err != nil {
-		return "", fmt.Errorf("failed to create request: %w", err)
+	return MultiSolver{
+		Solvers:       cfg.CaptchaSolvers,
+		Strategy:      cfg.CaptchaStrategy,
+		MinConfidence: cfg.CaptchaMinConfidence,
 	}
-	req.Header.Set("Content-Type", writer.FormDataContentType())
+}
 
-	client := &http.Client{Timeout: 30 * time.Second}
-	resp, err := client.Do(req)
+// solveCaptcha captures the on-page CAPTCHA image and runs it through
+// cfg's configured solver(s), replacing the old fastProcessCaptcha
+// (single OCR.space call).
+func solveCaptcha(ctx context.Context, cfg ScraperConfig) (string, error) {
+	imgPNG, err := captureCaptchaImage(ctx)
 	if err != nil {
-		return "", fmt.Errorf("OCR request failed: %w", err)
+		return "", err
 	}
-	defer resp.Body.Close()
 
-	var result map[string]interface{}
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return "", fmt.Errorf("failed to decode OCR response: %w", err)
+	solver := captchaSolver(cfg)
+	start := time.Now()
+	text, confidence, err := solver.Solve(ctx, imgPNG)
+	latency := time.Since(start)
+
+	if cfg.Store != nil {
+		attempt := CaptchaAttempt{
+			ImageHash: captchaImageHash(imgPNG),
+			OCRText:   text,
+			Success:   err == nil,
+			LatencyMS: latency.Milliseconds(),
+			Solver:    fmt.Sprintf("%T", solver),
+			Time:      time.Now(),
+		}
+		if recErr := cfg.Store.RecordCaptchaAttempt(ctx, attempt); recErr != nil {
+			LogToFile("⚠️ Failed to record captcha attempt: %v", recErr)
+		}
 	}
 
-	// Better error handling for OCR response
-	if parsed, ok := result["ParsedResults"].([]interface{}); ok && len(parsed) > 0 {
-		if data, ok := parsed[0].(map[string]interface{}); ok {
-			if text, ok := data["ParsedText"].(string); ok {
-				cleanedText := strings.ToLower(strings.TrimSpace(text))
-				if cleanedText == "" {
-					return "", fmt.Errorf("OCR returned empty text")
-				}
-				return cleanedText, nil
-			}
-		}
+	if err != nil {
+		return "", fmt.Errorf("captcha solve failed: %w", err)
 	}
+	LogToFile("🔐 Captcha solved with confidence %.2f", confidence)
+	return text, nil
+}
 
-	// Check for OCR error messages
-	if errMsg, ok := result["ErrorMessage"].([]interface{}); ok && len(errMsg) > 0 {
-		return "", fmt.Errorf("OCR API error: %v", errMsg)
+func handlePreSaleCode(ctx context.Context, preSaleCode string, b browser.Browser) error {
+	if b == nil {
+		b = browser.ChromedpBrowser{}
 	}
 
-	return "", fmt.Errorf("no text found in OCR response")
-}
+	LogToFile("🔍 Checking for pre-sale code form...")
 
-func saveBooking(booking Booking) {
-	fileMutex.Lock()
-	defer fileMutex.Unlock()
+	// Wait a bit for page to load
+	time.Sleep(1 * time.Second)
 
-	// Create bookings directory if it doesn't exist
-	if err := os.MkdirAll("data", 0755); err != nil {
-		LogToFile("❌ Failed to create data directory: %v", err)
-		return
+	var hasForm bool
+	err := b.Eval(ctx, `
+            (function() {
+                const form = document.getElementById('form-ticket-verify');
+                return form !== null && form.offsetParent !== null;
+            })()
+        `, &hasForm)
+	if err != nil {
+		return fmt.Errorf("failed to check for pre-sale form: %w", err)
 	}
 
-	var bookings []Booking
-	data, err := os.ReadFile("data/bookings.json")
-	if err != nil && !os.IsNotExist(err) {
-		LogToFile("❌ Error reading bookings file: %v", err)
-		return
+	if !hasForm {
+		LogToFile("✅ No pre-sale code form found, continuing...")
+		return nil
 	}
 
-	if len(data) > 0 {
-		if err := json.Unmarshal(data, &bookings); err != nil {
-			LogToFile("❌ Error unmarshaling existing bookings: %v", err)
-			// Continue with empty bookings array
-			bookings = []Booking{}
-		}
+	LogToFile("🔑 Pre-sale code form detected, entering code...")
+
+	if preSaleCode == "" {
+		return fmt.Errorf("pre-sale code form found but no code provided")
 	}
 
-	bookings = append(bookings, booking)
-	updatedData, err := json.MarshalIndent(bookings, "", "  ")
+	// Fill and submit the pre-sale code form. SetValue isn't part of the
+	// Browser interface (only chromedp exposes it directly), so this still
+	// goes through chromedp.Run regardless of which Browser backend is
+	// selected.
+	if err := b.WaitVisible(ctx, "#form-ticket-verify"); err != nil {
+		return fmt.Errorf("failed to submit pre-sale code: %w", err)
+	}
+	err = chromedp.Run(ctx,
+		chromedp.SetValue("input[name='checkCode']", preSaleCode, chromedp.ByQuery),
+	)
 	if err != nil {
-		LogToFile("❌ Error marshaling bookings: %v", err)
-		return
+		return fmt.Errorf("failed to submit pre-sale code: %w", err)
 	}
-
-	if err := os.WriteFile("data/bookings.json", updatedData, 0644); err != nil {
-		LogToFile("❌ Error writing bookings file: %v", err)
-		return
+	if err := b.Click(ctx, "#form-ticket-verify button[type='submit']"); err != nil {
+		return fmt.Errorf("failed to submit pre-sale code: %w", err)
 	}
+	time.Sleep(1 * time.Second) // Wait for form submission
 
-	LogToFile("✅ Booking saved to file. Order: %s", booking.OrderNumber)
+	LogToFile("✅ Pre-sale code submitted successfully")
+	return nil
 }
 
-func handlePreSaleCode(ctx context.Context, preSaleCode string) error {
-	LogToFile("🔍 Checking for pre-sale code form...")
-
-	// Wait a bit for page to load
-	time.Sleep(1 * time.Second)
+// handleTOTPCode mirrors handlePreSaleCode: it's a no-op unless the vendor's
+// page actually shows a 2FA input, in which case it fetches the current
+// code from cfg.TOTP and types it in.
+func handleTOTPCode(ctx context.Context, cfg ScraperConfig) error {
+	LogToFile("🔍 Checking for 2FA code form...")
 
 	var hasForm bool
 	err := chromedp.Run(ctx,
 		chromedp.Evaluate(`
             (function() {
-                const form = document.getElementById('form-ticket-verify');
+                const form = document.getElementById('form-totp-verify');
                 return form !== null && form.offsetParent !== null;
             })()
         `, &hasForm),
 	)
 	if err != nil {
-		return fmt.Errorf("failed to check for pre-sale form: %w", err)
+		return fmt.Errorf("failed to check for 2FA form: %w", err)
 	}
 
 	if !hasForm {
-		LogToFile("✅ No pre-sale code form found, continuing...")
+		LogToFile("✅ No 2FA form found, continuing...")
 		return nil
 	}
 
-	LogToFile("🔑 Pre-sale code form detected, entering code...")
+	LogToFile("🔐 2FA form detected, fetching code...")
 
-	if preSaleCode == "" {
-		return fmt.Errorf("pre-sale code form found but no code provided")
+	if cfg.TOTP == nil || cfg.TOTPSite == "" {
+		return fmt.Errorf("2FA form found but no 2FA site is configured for this bot")
+	}
+
+	code, _, err := cfg.TOTP.Code(cfg.TOTPSite, time.Now())
+	if err != nil {
+		return fmt.Errorf("generating 2FA code: %w", err)
 	}
 
-	// Fill and submit the pre-sale code form
 	err = chromedp.Run(ctx,
-		chromedp.WaitVisible("#form-ticket-verify", chromedp.ByID),
-		chromedp.SetValue("input[name='checkCode']", preSaleCode, chromedp.ByQuery),
-		chromedp.Click("#form-ticket-verify button[type='submit']", chromedp.ByQuery),
-		chromedp.Sleep(1*time.Second), // Wait for form submission
+		chromedp.WaitVisible("#form-totp-verify", chromedp.ByID),
+		chromedp.SetValue("input[name='totpCode']", code, chromedp.ByQuery),
+		chromedp.Click("#form-totp-verify button[type='submit']", chromedp.ByQuery),
+		chromedp.Sleep(1*time.Second),
 	)
 	if err != nil {
-		return fmt.Errorf("failed to submit pre-sale code: %w", err)
+		return fmt.Errorf("failed to submit 2FA code: %w", err)
 	}
 
-	LogToFile("✅ Pre-sale code submitted successfully")
+	LogToFile("✅ 2FA code submitted successfully")
 	return nil
 }
 
@@ -1211,7 +1447,11 @@ func generateUUID() string {
 }
 
 // Update GetUserName to use TIXUISID
-func GetUserName(session_id string) (string, error) {
+func GetUserName(session_id string, b browser.Browser) (string, error) {
+	if b == nil {
+		b = browser.ChromedpBrowser{}
+	}
+
 	options := append(
 		chromedp.DefaultExecAllocatorOptions[:],
 		chromedp.Flag("disable-blink-features", "AutomationControlled"),
@@ -1232,18 +1472,22 @@ func GetUserName(session_id string) (string, error) {
 	timeoutCtx, timeoutCancel := context.WithTimeout(browserCtx, 30*time.Second)
 	defer timeoutCancel()
 
-	var username string
-
-	err := chromedp.Run(timeoutCtx,
-		// Set both cookies for compatibility
-		network.SetCookie("SID", session_id).WithDomain("tixcraft.com").WithPath("/"),
-		network.SetCookie("TIXUISID", session_id).WithDomain("tixcraft.com").WithPath("/"),
-		chromedp.Navigate("https://tixcraft.com"),
-		chromedp.WaitVisible("#header", chromedp.ByQueryAll),
-		chromedp.Text(".user-name", &username, chromedp.ByQuery),
-	)
+	// Set both cookies for compatibility
+	if err := b.SetCookie(timeoutCtx, browser.Cookie{Name: "SID", Value: session_id, Domain: "tixcraft.com", Path: "/"}); err != nil {
+		return "", fmt.Errorf("navigation failed: %w", err)
+	}
+	if err := b.SetCookie(timeoutCtx, browser.Cookie{Name: "TIXUISID", Value: session_id, Domain: "tixcraft.com", Path: "/"}); err != nil {
+		return "", fmt.Errorf("navigation failed: %w", err)
+	}
+	if err := b.Navigate(timeoutCtx, "https://tixcraft.com"); err != nil {
+		return "", fmt.Errorf("navigation failed: %w", err)
+	}
+	if err := b.WaitVisible(timeoutCtx, "#header"); err != nil {
+		return "", fmt.Errorf("navigation failed: %w", err)
+	}
 
-	if err != nil {
+	var username string
+	if err := b.Eval(timeoutCtx, `document.querySelector('.user-name').textContent`, &username); err != nil {
 		return "", fmt.Errorf("navigation failed: %w", err)
 	}
 
@@ -0,0 +1,191 @@
+//go:build sqlite
+
+package services
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	_ "modernc.org/sqlite"
+)
+
+// SQLiteBookingStore is a BookingStore backed by modernc.org/sqlite (pure
+// Go, no cgo, unlike mattn/go-sqlite3), replacing JSONFileSink's
+// read-whole-file-modify-rewrite approach with real concurrent writes: the
+// driver serializes at the database level instead of this package
+// serializing every Publish behind JSONFileSink's mutex. This file only builds
+// with `-tags sqlite`, since modernc.org/sqlite isn't a dependency of this
+// module by default — see sqlite_store_stub.go for the plain build.
+//
+// This was written without a network connection to check the installed
+// modernc.org/sqlite API surface against; double-check method names/
+// signatures against the version you vendor before relying on it.
+type SQLiteBookingStore struct {
+	DB *sql.DB
+}
+
+// NewSQLiteBookingStore opens (creating if necessary) a SQLite database at
+// dbPath, ensures its schema exists, and — only the first time, i.e. only
+// if the bookings table is still empty — imports every booking already in
+// jsonPath (the pre-existing data/bookings.json), so switching a deployment
+// over to SQLite doesn't lose booking history. jsonPath may be empty to
+// skip the migration.
+func NewSQLiteBookingStore(dbPath, jsonPath string) (*SQLiteBookingStore, error) {
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		return nil, fmt.Errorf("bookingstore: opening %s: %w", dbPath, err)
+	}
+
+	store := &SQLiteBookingStore{DB: db}
+	if err := store.ensureSchema(context.Background()); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	if jsonPath != "" {
+		if err := store.migrateFromJSON(context.Background(), jsonPath); err != nil {
+			db.Close()
+			return nil, err
+		}
+	}
+
+	return store, nil
+}
+
+func (s *SQLiteBookingStore) ensureSchema(ctx context.Context) error {
+	_, err := s.DB.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS bookings (
+		session_id TEXT, seat TEXT, event_id TEXT, ticket_id TEXT,
+		num_of_tickets TEXT, order_number TEXT PRIMARY KEY, event_name TEXT,
+		event_date TEXT, event_venue TEXT, section TEXT, seat_info TEXT,
+		ticket_info TEXT, ticket_qty TEXT, service_fee TEXT, total TEXT,
+		username TEXT
+	)`)
+	if err != nil {
+		return fmt.Errorf("bookingstore: creating bookings table: %w", err)
+	}
+
+	_, err = s.DB.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS captcha_attempts (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		image_hash TEXT, ocr_text TEXT, success INTEGER,
+		latency_ms INTEGER, solver TEXT, attempted_at DATETIME
+	)`)
+	if err != nil {
+		return fmt.Errorf("bookingstore: creating captcha_attempts table: %w", err)
+	}
+	return nil
+}
+
+// migrateFromJSON is a no-op once the bookings table has any rows, so it's
+// safe to call on every startup.
+func (s *SQLiteBookingStore) migrateFromJSON(ctx context.Context, jsonPath string) error {
+	var count int
+	if err := s.DB.QueryRowContext(ctx, `SELECT COUNT(*) FROM bookings`).Scan(&count); err != nil {
+		return fmt.Errorf("bookingstore: counting existing bookings: %w", err)
+	}
+	if count > 0 {
+		return nil
+	}
+
+	data, err := os.ReadFile(jsonPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("bookingstore: reading %s: %w", jsonPath, err)
+	}
+
+	var bookings []Booking
+	if err := json.Unmarshal(data, &bookings); err != nil {
+		return fmt.Errorf("bookingstore: parsing %s: %w", jsonPath, err)
+	}
+
+	for _, booking := range bookings {
+		if err := s.Insert(ctx, booking); err != nil {
+			return fmt.Errorf("bookingstore: migrating booking %s: %w", booking.OrderNumber, err)
+		}
+	}
+	return nil
+}
+
+// Publish implements BookingSink so a SQLiteBookingStore can sit directly
+// in cfg.Sinks alongside JSONFileSink/WebhookSink/etc.
+func (s *SQLiteBookingStore) Publish(ctx context.Context, booking Booking) error {
+	return s.Insert(ctx, booking)
+}
+
+func (s *SQLiteBookingStore) Insert(ctx context.Context, booking Booking) error {
+	_, err := s.DB.ExecContext(ctx, `INSERT OR REPLACE INTO bookings (
+		session_id, seat, event_id, ticket_id, num_of_tickets, order_number,
+		event_name, event_date, event_venue, section, seat_info, ticket_info,
+		ticket_qty, service_fee, total, username
+	) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		booking.SessionID, booking.Seat, booking.EventID, booking.TicketID,
+		booking.NumOfTickets, booking.OrderNumber, booking.EventName,
+		booking.EventDate, booking.EventVenue, booking.Section, booking.SeatInfo,
+		booking.TicketInfo, booking.TicketQty, booking.ServiceFee, booking.Total,
+		booking.UserName)
+	if err != nil {
+		return fmt.Errorf("bookingstore: inserting booking: %w", err)
+	}
+	return nil
+}
+
+func (s *SQLiteBookingStore) List(ctx context.Context) ([]Booking, error) {
+	rows, err := s.DB.QueryContext(ctx, `SELECT
+		session_id, seat, event_id, ticket_id, num_of_tickets, order_number,
+		event_name, event_date, event_venue, section, seat_info, ticket_info,
+		ticket_qty, service_fee, total, username
+	FROM bookings`)
+	if err != nil {
+		return nil, fmt.Errorf("bookingstore: listing bookings: %w", err)
+	}
+	defer rows.Close()
+
+	var bookings []Booking
+	for rows.Next() {
+		var b Booking
+		if err := rows.Scan(
+			&b.SessionID, &b.Seat, &b.EventID, &b.TicketID,
+			&b.NumOfTickets, &b.OrderNumber, &b.EventName,
+			&b.EventDate, &b.EventVenue, &b.Section, &b.SeatInfo,
+			&b.TicketInfo, &b.TicketQty, &b.ServiceFee, &b.Total, &b.UserName,
+		); err != nil {
+			return nil, fmt.Errorf("bookingstore: scanning booking row: %w", err)
+		}
+		bookings = append(bookings, b)
+	}
+	return bookings, rows.Err()
+}
+
+func (s *SQLiteBookingStore) GetByOrder(ctx context.Context, orderNumber string) (Booking, error) {
+	var b Booking
+	err := s.DB.QueryRowContext(ctx, `SELECT
+		session_id, seat, event_id, ticket_id, num_of_tickets, order_number,
+		event_name, event_date, event_venue, section, seat_info, ticket_info,
+		ticket_qty, service_fee, total, username
+	FROM bookings WHERE order_number = ?`, orderNumber).Scan(
+		&b.SessionID, &b.Seat, &b.EventID, &b.TicketID,
+		&b.NumOfTickets, &b.OrderNumber, &b.EventName,
+		&b.EventDate, &b.EventVenue, &b.Section, &b.SeatInfo,
+		&b.TicketInfo, &b.TicketQty, &b.ServiceFee, &b.Total, &b.UserName,
+	)
+	if err != nil {
+		return Booking{}, fmt.Errorf("bookingstore: getting order %s: %w", orderNumber, err)
+	}
+	return b, nil
+}
+
+func (s *SQLiteBookingStore) RecordCaptchaAttempt(ctx context.Context, attempt CaptchaAttempt) error {
+	_, err := s.DB.ExecContext(ctx, `INSERT INTO captcha_attempts (
+		image_hash, ocr_text, success, latency_ms, solver, attempted_at
+	) VALUES (?, ?, ?, ?, ?, ?)`,
+		attempt.ImageHash, attempt.OCRText, attempt.Success,
+		attempt.LatencyMS, attempt.Solver, attempt.Time)
+	if err != nil {
+		return fmt.Errorf("bookingstore: recording captcha attempt: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+//go:build !sqlite
+
+package services
+
+import (
+	"context"
+	"fmt"
+)
+
+// SQLiteBookingStore is the no-tag stand-in for sqlite_store.go's real
+// implementation, so ScraperConfig.Store and config-driven store selection
+// can reference SQLiteBookingStore unconditionally. Build with `-tags
+// sqlite` (and modernc.org/sqlite, not a dependency of this module by
+// default) to get a working SQLite-backed store; otherwise every method
+// just reports why it can't run.
+type SQLiteBookingStore struct{}
+
+func NewSQLiteBookingStore(dbPath, jsonPath string) (*SQLiteBookingStore, error) {
+	return nil, fmt.Errorf("bookingstore: SQLiteBookingStore requires building with -tags sqlite")
+}
+
+func (s *SQLiteBookingStore) Publish(ctx context.Context, booking Booking) error {
+	return fmt.Errorf("bookingstore: SQLiteBookingStore requires building with -tags sqlite")
+}
+
+func (s *SQLiteBookingStore) Insert(ctx context.Context, booking Booking) error {
+	return fmt.Errorf("bookingstore: SQLiteBookingStore requires building with -tags sqlite")
+}
+
+func (s *SQLiteBookingStore) List(ctx context.Context) ([]Booking, error) {
+	return nil, fmt.Errorf("bookingstore: SQLiteBookingStore requires building with -tags sqlite")
+}
+
+func (s *SQLiteBookingStore) GetByOrder(ctx context.Context, orderNumber string) (Booking, error) {
+	return Booking{}, fmt.Errorf("bookingstore: SQLiteBookingStore requires building with -tags sqlite")
+}
+
+func (s *SQLiteBookingStore) RecordCaptchaAttempt(ctx context.Context, attempt CaptchaAttempt) error {
+	return fmt.Errorf("bookingstore: SQLiteBookingStore requires building with -tags sqlite")
+}
@@ -0,0 +1,232 @@
+// Package supervisor wraps a long-running task (typically a scraper run)
+// with exponential backoff retries and a per-key circuit breaker, so a bot
+// that fails repeatedly against the same event doesn't hammer it forever.
+package supervisor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// State is a coarse label for the supervisor's current activity, intended
+// to be surfaced by a control plane (e.g. the BotManager control API).
+type State string
+
+const (
+	StateRunning     State = "running"
+	StateBackoff     State = "backoff"
+	StateCircuitOpen State = "circuit-open"
+	StateStopped     State = "stopped"
+	StateFailed      State = "failed"
+)
+
+// RetryPolicy configures exponential backoff with jitter for a single bot.
+type RetryPolicy struct {
+	MaxRetries              int `json:"max_retries"`
+	BaseDelaySeconds        int `json:"base_delay_seconds"`
+	MaxDelaySeconds         int `json:"max_delay_seconds"`
+	MaxTotalDurationSeconds int `json:"max_total_duration_seconds"` // 0 = unlimited
+}
+
+// DefaultRetryPolicy mirrors what a first-time bot operator would want:
+// a handful of quick retries before giving up.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxRetries:       5,
+		BaseDelaySeconds: 2,
+		MaxDelaySeconds:  60,
+	}
+}
+
+func (p RetryPolicy) delay(attempt int) time.Duration {
+	base := p.BaseDelaySeconds
+	if base <= 0 {
+		base = 1
+	}
+	max := p.MaxDelaySeconds
+	if max <= 0 {
+		max = 60
+	}
+
+	backoff := float64(base) * float64(int(1)<<uint(attempt))
+	if backoff > float64(max) {
+		backoff = float64(max)
+	}
+
+	jitter := backoff * (0.5 + rand.Float64()*0.5)
+	return time.Duration(jitter * float64(time.Second))
+}
+
+// CircuitBreakerConfig trips a shared breaker for a key (typically an
+// EventID) after FailureThreshold consecutive failures, pausing every
+// supervisor watching that key for CooldownSeconds.
+type CircuitBreakerConfig struct {
+	FailureThreshold int `json:"failure_threshold"`
+	CooldownSeconds  int `json:"cooldown_seconds"`
+}
+
+// DefaultCircuitBreaker opens after 5 consecutive failures and cools down
+// for five minutes.
+func DefaultCircuitBreaker() CircuitBreakerConfig {
+	return CircuitBreakerConfig{FailureThreshold: 5, CooldownSeconds: 300}
+}
+
+func (c CircuitBreakerConfig) enabled() bool {
+	return c.FailureThreshold > 0
+}
+
+type breakerState struct {
+	mu                  sync.Mutex
+	consecutiveFailures int
+	openUntil           time.Time
+}
+
+// Registry tracks circuit breaker state shared across every supervisor
+// targeting the same key, so one bot's failures can pause its siblings.
+type Registry struct {
+	mu       sync.Mutex
+	breakers map[string]*breakerState
+}
+
+// NewRegistry creates an empty breaker registry. A process typically keeps
+// one shared Registry for all of its bots.
+func NewRegistry() *Registry {
+	return &Registry{breakers: make(map[string]*breakerState)}
+}
+
+func (r *Registry) state(key string) *breakerState {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	b, ok := r.breakers[key]
+	if !ok {
+		b = &breakerState{}
+		r.breakers[key] = b
+	}
+	return b
+}
+
+// openFor reports whether the breaker for key is currently open, and if so
+// how much longer until it closes.
+func (r *Registry) openFor(key string) (bool, time.Duration) {
+	b := r.state(key)
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.openUntil.IsZero() || time.Now().After(b.openUntil) {
+		return false, 0
+	}
+	return true, time.Until(b.openUntil)
+}
+
+func (r *Registry) recordFailure(key string, cfg CircuitBreakerConfig) bool {
+	if !cfg.enabled() {
+		return false
+	}
+
+	b := r.state(key)
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.consecutiveFailures++
+	if b.consecutiveFailures >= cfg.FailureThreshold {
+		b.openUntil = time.Now().Add(time.Duration(cfg.CooldownSeconds) * time.Second)
+		b.consecutiveFailures = 0
+		return true
+	}
+	return false
+}
+
+func (r *Registry) recordSuccess(key string) {
+	b := r.state(key)
+	b.mu.Lock()
+	b.consecutiveFailures = 0
+	b.mu.Unlock()
+}
+
+// Supervisor retries a task with exponential backoff and participates in a
+// shared circuit breaker keyed by BreakerKey (typically the bot's EventID).
+type Supervisor struct {
+	BreakerKey   string
+	Retry        RetryPolicy
+	Breaker      CircuitBreakerConfig
+	Registry     *Registry
+	OnTransition func(state State, detail string)
+}
+
+func (s *Supervisor) emit(state State, detail string) {
+	if s.OnTransition != nil {
+		s.OnTransition(state, detail)
+	}
+}
+
+// Run executes task, retrying with backoff on error until it succeeds, the
+// retry budget is exhausted, or ctx is cancelled. Before each attempt it
+// checks (and waits out) the shared circuit breaker for BreakerKey.
+func (s *Supervisor) Run(ctx context.Context, task func(context.Context) error) error {
+	registry := s.Registry
+	if registry == nil {
+		registry = NewRegistry()
+	}
+
+	start := time.Now()
+	maxDuration := time.Duration(s.Retry.MaxTotalDurationSeconds) * time.Second
+
+	for attempt := 0; ; attempt++ {
+		if open, remaining := registry.openFor(s.BreakerKey); open {
+			s.emit(StateCircuitOpen, fmt.Sprintf("event %q paused for %v after repeated failures", s.BreakerKey, remaining.Round(time.Second)))
+			if err := sleep(ctx, remaining); err != nil {
+				return err
+			}
+		}
+
+		s.emit(StateRunning, fmt.Sprintf("attempt %d", attempt+1))
+		err := task(ctx)
+		if err == nil {
+			registry.recordSuccess(s.BreakerKey)
+			s.emit(StateStopped, "completed successfully")
+			return nil
+		}
+
+		if errors.Is(err, context.Canceled) || ctx.Err() != nil {
+			s.emit(StateStopped, "cancelled")
+			return err
+		}
+
+		if registry.recordFailure(s.BreakerKey, s.Breaker) {
+			s.emit(StateCircuitOpen, fmt.Sprintf("circuit opened for event %q after %d consecutive failures", s.BreakerKey, s.Breaker.FailureThreshold))
+		}
+
+		if attempt >= s.Retry.MaxRetries {
+			s.emit(StateFailed, fmt.Sprintf("giving up after %d attempts: %v", attempt+1, err))
+			return fmt.Errorf("exhausted %d retries: %w", s.Retry.MaxRetries, err)
+		}
+
+		if maxDuration > 0 && time.Since(start) > maxDuration {
+			s.emit(StateFailed, fmt.Sprintf("exceeded max total duration %v: %v", maxDuration, err))
+			return fmt.Errorf("exceeded max total duration %v: %w", maxDuration, err)
+		}
+
+		delay := s.Retry.delay(attempt)
+		s.emit(StateBackoff, fmt.Sprintf("retrying in %v after: %v", delay.Round(time.Second), err))
+		if err := sleep(ctx, delay); err != nil {
+			return err
+		}
+	}
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
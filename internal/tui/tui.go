@@ -0,0 +1,133 @@
+// Package tui is the terminal frontend for tix-scraper: a bots pane, a
+// logs pane, and start/stop control, for running over SSH or on headless
+// CI boxes where Gio can't open a window. It drives the same *gui.GUI
+// state the Gio window does, entirely through the gui.UIFrontend
+// interface, so logView.Write, bot lifecycle, and state updates are
+// genuinely shared between the two frontends rather than reimplemented.
+//
+// This is a line-based command shell, not a curses-style full-screen UI:
+// tview and gocui are not in go.mod, and this environment has no network
+// access to add them, so a real dependency on either can't be verified
+// here. Reading one command per line from stdin is the honest substitute
+// until one of those packages is actually vendored.
+package tui
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"tix-scraper/internal/gui"
+	"tix-scraper/internal/logbus"
+)
+
+// Run drives frontend as the terminal UI, reading commands from in and
+// writing output to out until the user quits or in is closed. Callers
+// that also want the HTTP dashboard (or a future frontend) watching the
+// same bots should construct one *gui.GUI and pass it to both, rather
+// than each frontend building its own.
+func Run(frontend gui.UIFrontend, in io.Reader, out io.Writer) error {
+	frontend.LogBus().AddSink(&stdoutSink{w: out})
+
+	fmt.Fprintln(out, "tix-scraper terminal mode. Type 'help' for commands.")
+	printBots(frontend, out)
+
+	scanner := bufio.NewScanner(in)
+	for {
+		fmt.Fprint(out, "> ")
+		if !scanner.Scan() {
+			return scanner.Err()
+		}
+
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		cmd, args := fields[0], fields[1:]
+
+		switch cmd {
+		case "help":
+			printHelp(out)
+		case "bots", "ls":
+			printBots(frontend, out)
+		case "start":
+			runOn(frontend, args, frontend.StartBot, out)
+		case "stop":
+			runOn(frontend, args, frontend.StopBot, out)
+		case "pause":
+			fmt.Fprintln(out, "pause is not supported: bots only have a start/stop lifecycle, use 'stop' instead")
+		case "quit", "exit":
+			return nil
+		default:
+			fmt.Fprintf(out, "unknown command: %s (type 'help')\n", cmd)
+		}
+	}
+}
+
+// runOn resolves args[0] to a bot ID and calls fn with it, printing usage
+// or the resulting error to out rather than returning it, so one bad
+// command doesn't end the session.
+func runOn(frontend gui.UIFrontend, args []string, fn func(id string) error, out io.Writer) {
+	if len(args) != 1 {
+		fmt.Fprintln(out, "usage: start|stop <bot-index-or-id>")
+		return
+	}
+	if err := fn(resolveID(frontend, args[0])); err != nil {
+		fmt.Fprintf(out, "error: %v\n", err)
+	}
+}
+
+// resolveID lets commands name a bot either by its display index (as
+// shown by printBots) or its full ID, since IDs aren't something a user
+// typing at a terminal can be expected to remember.
+func resolveID(frontend gui.UIFrontend, arg string) string {
+	if idx, err := strconv.Atoi(arg); err == nil {
+		if bots := frontend.Bots(); idx >= 0 && idx < len(bots) {
+			return bots[idx].ID
+		}
+	}
+	return arg
+}
+
+func printBots(frontend gui.UIFrontend, out io.Writer) {
+	bots := frontend.Bots()
+	if len(bots) == 0 {
+		fmt.Fprintln(out, "no bots configured")
+		return
+	}
+	for i, bot := range bots {
+		status := "stopped"
+		if bot.IsRunning {
+			status = "running"
+		}
+		fmt.Fprintf(out, "  [%d] %-20s %-8s id=%s\n", i, bot.Name, status, bot.ID)
+	}
+}
+
+func printHelp(out io.Writer) {
+	fmt.Fprint(out, `Commands:
+  bots            list configured bots and their status
+  start <n|id>    start bot by index (from 'bots') or full ID
+  stop  <n|id>    stop bot by index or ID
+  help            show this message
+  quit            exit
+`)
+}
+
+// stdoutSink is the logs pane: a logbus.LogSink that prints every entry
+// as a single line, the same information the GUI's LogView would render
+// as a colored badge and message.
+type stdoutSink struct {
+	w io.Writer
+}
+
+func (s *stdoutSink) Publish(entry logbus.LogEntry) error {
+	line := entry.Timestamp.Format("15:04:05") + " [" + entry.Level.String() + "]"
+	if entry.BotName != "" {
+		line += " [" + entry.BotName + "]"
+	}
+	_, err := fmt.Fprintln(s.w, line+" "+entry.Message)
+	return err
+}
@@ -0,0 +1,276 @@
+// Package vault provides an encrypted-at-rest JSON store protected by a
+// master passphrase, used to keep tixcraft SID session cookies out of a
+// world-readable plaintext file. A key is derived from the passphrase with
+// argon2id and cached in memory only for the current session.
+package vault
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+var magic = [8]byte{'T', 'I', 'X', 'V', 'L', 'T', 0, 1}
+
+const saltSize = 16
+
+// ErrLocked is returned by Load and Save when called before Unlock, or
+// after the vault has auto-locked from inactivity.
+var ErrLocked = errors.New("vault: locked, call Unlock first")
+
+// Vault is an encrypted JSON store backed by a single file laid out as
+// magic || salt || nonce || ciphertext+tag.
+type Vault struct {
+	path        string
+	lockTimeout time.Duration
+
+	mu           sync.Mutex
+	key          []byte
+	salt         []byte
+	lastActivity time.Time
+}
+
+// New returns a Vault backed by path. A zero lockTimeout disables
+// auto-locking from inactivity.
+func New(path string, lockTimeout time.Duration) *Vault {
+	return &Vault{path: path, lockTimeout: lockTimeout}
+}
+
+// Exists reports whether path already holds an encrypted vault file, as
+// opposed to no file or a legacy plaintext one.
+func (v *Vault) Exists() bool {
+	data, err := os.ReadFile(v.path)
+	if err != nil || len(data) < len(magic) {
+		return false
+	}
+	return [8]byte(data[:8]) == magic
+}
+
+// Unlock derives the vault key from passphrase. If the vault file doesn't
+// exist yet, a fresh random salt is generated and this passphrase becomes
+// the master password; otherwise the stored salt is reused and an incorrect
+// passphrase will simply fail to decrypt on the next Load.
+func (v *Vault) Unlock(passphrase string) error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	salt, err := v.readSalt()
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		salt = make([]byte, saltSize)
+		if _, err := rand.Read(salt); err != nil {
+			return fmt.Errorf("vault: generating salt: %w", err)
+		}
+	}
+
+	v.salt = salt
+	v.key = deriveKey(passphrase, salt)
+	v.lastActivity = time.Now()
+	return nil
+}
+
+func (v *Vault) readSalt() ([]byte, error) {
+	data, err := os.ReadFile(v.path)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < len(magic)+saltSize {
+		return nil, fmt.Errorf("vault: file too short or corrupted")
+	}
+	return data[len(magic) : len(magic)+saltSize], nil
+}
+
+func deriveKey(passphrase string, salt []byte) []byte {
+	return argon2.IDKey([]byte(passphrase), salt, 1, 64*1024, 4, chacha20poly1305.KeySize)
+}
+
+// Locked reports whether the vault key has been cleared, either explicitly
+// via Lock or because the idle timeout has elapsed.
+func (v *Vault) Locked() bool {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.locked()
+}
+
+// locked must be called with v.mu held.
+func (v *Vault) locked() bool {
+	if v.key == nil {
+		return true
+	}
+	if v.lockTimeout > 0 && time.Since(v.lastActivity) > v.lockTimeout {
+		v.zeroize()
+		return true
+	}
+	return false
+}
+
+// Lock zeroizes the in-memory key, requiring Unlock again before the next
+// Load or Save.
+func (v *Vault) Lock() {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.zeroize()
+}
+
+// zeroize must be called with v.mu held.
+func (v *Vault) zeroize() {
+	for i := range v.key {
+		v.key[i] = 0
+	}
+	v.key = nil
+}
+
+// Load decrypts the vault file and unmarshals it into out. A missing file
+// leaves out untouched and returns nil, matching a first-run empty vault.
+func (v *Vault) Load(out interface{}) error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if v.locked() {
+		return ErrLocked
+	}
+	v.lastActivity = time.Now()
+
+	data, err := os.ReadFile(v.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("vault: reading %s: %w", v.path, err)
+	}
+
+	plaintext, err := v.decrypt(data)
+	if err != nil {
+		return err
+	}
+	if len(plaintext) == 0 {
+		return nil
+	}
+	return json.Unmarshal(plaintext, out)
+}
+
+// Save marshals value as JSON, encrypts it under the current key, and
+// atomically writes it to the vault file via a temp-file-then-rename.
+func (v *Vault) Save(value interface{}) error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if v.locked() {
+		return ErrLocked
+	}
+	v.lastActivity = time.Now()
+	return v.saveLocked(value)
+}
+
+// saveLocked must be called with v.mu held and the vault unlocked.
+func (v *Vault) saveLocked(value interface{}) error {
+	plaintext, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("vault: marshalling: %w", err)
+	}
+
+	ciphertext, err := v.encrypt(plaintext)
+	if err != nil {
+		return err
+	}
+
+	if dir := filepath.Dir(v.path); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("vault: creating directory: %w", err)
+		}
+	}
+
+	tmp := v.path + ".tmp"
+	if err := os.WriteFile(tmp, ciphertext, 0600); err != nil {
+		return fmt.Errorf("vault: writing %s: %w", tmp, err)
+	}
+	if err := os.Rename(tmp, v.path); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("vault: renaming %s: %w", tmp, err)
+	}
+	return nil
+}
+
+func (v *Vault) encrypt(plaintext []byte) ([]byte, error) {
+	aead, err := chacha20poly1305.NewX(v.key)
+	if err != nil {
+		return nil, fmt.Errorf("vault: initializing cipher: %w", err)
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("vault: generating nonce: %w", err)
+	}
+
+	out := make([]byte, 0, len(magic)+len(v.salt)+len(nonce)+len(plaintext)+aead.Overhead())
+	out = append(out, magic[:]...)
+	out = append(out, v.salt...)
+	out = append(out, nonce...)
+	return aead.Seal(out, nonce, plaintext, nil), nil
+}
+
+func (v *Vault) decrypt(data []byte) ([]byte, error) {
+	if len(data) < len(magic)+saltSize {
+		return nil, fmt.Errorf("vault: file too short or corrupted")
+	}
+	if [8]byte(data[:8]) != magic {
+		return nil, fmt.Errorf("vault: not a vault file, migrate it first")
+	}
+
+	aead, err := chacha20poly1305.NewX(v.key)
+	if err != nil {
+		return nil, fmt.Errorf("vault: initializing cipher: %w", err)
+	}
+
+	rest := data[len(magic)+saltSize:]
+	if len(rest) < aead.NonceSize() {
+		return nil, fmt.Errorf("vault: file too short or corrupted")
+	}
+	nonce, ciphertext := rest[:aead.NonceSize()], rest[aead.NonceSize():]
+
+	plaintext, err := aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, fmt.Errorf("vault: decryption failed, wrong passphrase?")
+	}
+	return plaintext, nil
+}
+
+// ChangePassphrase re-encrypts value under a key derived from
+// newPassphrase and a fresh random salt. The vault must already be unlocked.
+func (v *Vault) ChangePassphrase(newPassphrase string, value interface{}) error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if v.locked() {
+		return ErrLocked
+	}
+
+	salt := make([]byte, saltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return fmt.Errorf("vault: generating salt: %w", err)
+	}
+	v.salt = salt
+	v.key = deriveKey(newPassphrase, salt)
+	v.lastActivity = time.Now()
+
+	return v.saveLocked(value)
+}
+
+// MigrateFromPlaintext parses legacy plaintext JSON data into out, then
+// encrypts and writes it under the currently unlocked passphrase. Call this
+// on first unlock when Exists reports false but a legacy file is present.
+func (v *Vault) MigrateFromPlaintext(data []byte, out interface{}) error {
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, out); err != nil {
+			return fmt.Errorf("vault: parsing legacy file: %w", err)
+		}
+	}
+	return v.Save(out)
+}
@@ -0,0 +1,151 @@
+// Package waits replaces chromedp.Sleep-based guessing with event-driven
+// waits built on chromedp.ListenTarget: instead of sleeping an arbitrary
+// duration after a navigation or form submit, these helpers block until
+// Chrome actually reports the event that matters (a frame navigation, a
+// load event, or a network response finishing) — a measurable latency win
+// when every second counts in a queue-based ticket drop.
+package waits
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/chromedp/cdproto/network"
+	"github.com/chromedp/cdproto/page"
+	"github.com/chromedp/chromedp"
+)
+
+// DefaultTimeout is used by every helper in this package when the caller
+// passes timeout <= 0.
+const DefaultTimeout = 15 * time.Second
+
+// Action adapts a waits helper into a chromedp.Action, so it can be
+// dropped into a chromedp.Run(...) action list in place of a
+// chromedp.Sleep call.
+func Action(fn func(ctx context.Context) error) chromedp.Action {
+	return chromedp.ActionFunc(fn)
+}
+
+// WaitForURLMatch blocks until the top-level frame navigates to a URL
+// matching re, or timeout elapses. It's built on WaitForNavigationTo, so
+// it returns the instant page.EventFrameNavigated reports the match
+// rather than polling chromedp.Location.
+func WaitForURLMatch(ctx context.Context, re *regexp.Regexp, timeout time.Duration) (string, error) {
+	return WaitForNavigationTo(ctx, re.MatchString, timeout)
+}
+
+// WaitForNavigationTo blocks until the top-level frame navigates to a URL
+// for which match returns true, or timeout elapses. match is called with
+// every top-level page.EventFrameNavigated URL seen while waiting.
+func WaitForNavigationTo(ctx context.Context, match func(url string) bool, timeout time.Duration) (string, error) {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+
+	listenCtx, cancelListen := context.WithCancel(ctx)
+	defer cancelListen()
+
+	matched := make(chan string, 1)
+	var once sync.Once
+
+	chromedp.ListenTarget(listenCtx, func(ev interface{}) {
+		nav, ok := ev.(*page.EventFrameNavigated)
+		if !ok || nav.Frame == nil || nav.Frame.ParentID != "" {
+			return
+		}
+		if match(nav.Frame.URL) {
+			once.Do(func() { matched <- nav.Frame.URL })
+		}
+	})
+
+	select {
+	case url := <-matched:
+		return url, nil
+	case <-time.After(timeout):
+		return "", fmt.Errorf("waits: no matching navigation within %s", timeout)
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+}
+
+// WaitForXHR blocks until a network response whose URL contains urlSubstr
+// finishes loading (network.EventResponseReceived followed by
+// network.EventLoadingFinished for the same request), or timeout elapses.
+// Useful for waiting on a form submission's AJAX call instead of sleeping
+// a guessed duration.
+func WaitForXHR(ctx context.Context, urlSubstr string, timeout time.Duration) error {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+
+	listenCtx, cancelListen := context.WithCancel(ctx)
+	defer cancelListen()
+
+	var mu sync.Mutex
+	matchingRequests := make(map[network.RequestID]bool)
+
+	done := make(chan struct{})
+	var once sync.Once
+
+	chromedp.ListenTarget(listenCtx, func(ev interface{}) {
+		switch e := ev.(type) {
+		case *network.EventResponseReceived:
+			if e.Response != nil && strings.Contains(e.Response.URL, urlSubstr) {
+				mu.Lock()
+				matchingRequests[e.RequestID] = true
+				mu.Unlock()
+			}
+		case *network.EventLoadingFinished:
+			mu.Lock()
+			hit := matchingRequests[e.RequestID]
+			mu.Unlock()
+			if hit {
+				once.Do(func() { close(done) })
+			}
+		}
+	})
+
+	select {
+	case <-done:
+		return nil
+	case <-time.After(timeout):
+		return fmt.Errorf("waits: no XHR matching %q finished within %s", urlSubstr, timeout)
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// WaitForLoadEvent blocks until page.EventLoadEventFired fires (the
+// browser's "load" event — every sub-resource finished), or timeout
+// elapses. This is the event-driven replacement for a blanket
+// chromedp.Sleep right after chromedp.Navigate.
+func WaitForLoadEvent(ctx context.Context, timeout time.Duration) error {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+
+	listenCtx, cancelListen := context.WithCancel(ctx)
+	defer cancelListen()
+
+	done := make(chan struct{})
+	var once sync.Once
+
+	chromedp.ListenTarget(listenCtx, func(ev interface{}) {
+		if _, ok := ev.(*page.EventLoadEventFired); ok {
+			once.Do(func() { close(done) })
+		}
+	})
+
+	select {
+	case <-done:
+		return nil
+	case <-time.After(timeout):
+		return fmt.Errorf("waits: load event did not fire within %s", timeout)
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}